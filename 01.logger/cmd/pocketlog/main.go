@@ -0,0 +1,291 @@
+// Command pocketlog reads the files written by a pocketlog.Logger, filters their entries and prints them back.
+//
+// Usage:
+//
+//	pocketlog [flags] [file...]
+//
+// With no file, the standard input is read.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"pocketlog/pocketlog"
+	"strings"
+	"time"
+)
+
+// pollInterval is how long follow mode waits for a file to grow.
+const pollInterval = 250 * time.Millisecond
+
+func main() {
+	var (
+		level  string
+		since  string
+		until  string
+		format string
+		tail   int
+		follow bool
+		fields = fieldsFlag{}
+	)
+
+	flag.StringVar(&level, "level", "debug", "Lowest level to print: debug, info or error")
+	flag.StringVar(&since, "since", "", "Only print entries written after this RFC 3339 time, or this long ago (e.g. 1h30m)")
+	flag.StringVar(&until, "until", "", "Only print entries written before this RFC 3339 time, or this long ago (e.g. 10m)")
+	flag.Var(fields, "field", "Only print entries holding this key=value field; can be repeated")
+	flag.StringVar(&format, "format", "text", "Output format: text or json")
+	flag.IntVar(&tail, "n", 0, "Only print the last n matching entries of each file")
+	flag.BoolVar(&follow, "f", false, "Keep reading the file as it grows")
+	flag.Parse()
+
+	filter, err := newFilter(level, since, until, fields)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid filter: %s\n", err)
+		os.Exit(2)
+	}
+
+	printer, err := newPrinter(os.Stdout, format)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid format: %s\n", err)
+		os.Exit(2)
+	}
+
+	q := &query{filter: filter, print: printer, tail: tail, follow: follow}
+
+	if flag.NArg() == 0 {
+		err = q.run(os.Stdin)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "unable to read standard input: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if follow && flag.NArg() > 1 {
+		_, _ = fmt.Fprintln(os.Stderr, "follow mode only supports a single file")
+		os.Exit(2)
+	}
+
+	for _, path := range flag.Args() {
+		err = q.runFile(path)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "unable to read %q: %s\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// fieldsFlag collects the key=value pairs given on the command line.
+type fieldsFlag map[string]string
+
+// String implements the flag.Value interface.
+func (f fieldsFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for key, value := range f {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set implements the flag.Value interface.
+func (f fieldsFlag) Set(pair string) error {
+	key, value, found := strings.Cut(pair, "=")
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got %q", pair)
+	}
+	f[key] = value
+	return nil
+}
+
+// newFilter builds the filter described by the command line.
+func newFilter(level, since, until string, fields map[string]string) (pocketlog.Filter, error) {
+	var (
+		filter = pocketlog.Filter{Fields: fields}
+		err    error
+	)
+
+	filter.MinLevel, err = pocketlog.ParseLevel(level)
+	if err != nil {
+		return pocketlog.Filter{}, err
+	}
+
+	filter.Since, err = parseTime(since)
+	if err != nil {
+		return pocketlog.Filter{}, fmt.Errorf("since: %w", err)
+	}
+
+	filter.Until, err = parseTime(until)
+	if err != nil {
+		return pocketlog.Filter{}, fmt.Errorf("until: %w", err)
+	}
+
+	return filter, nil
+}
+
+// parseTime reads either an RFC 3339 time or a duration counted back from now.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a time nor a duration", value)
+	}
+
+	return t, nil
+}
+
+// newPrinter returns a function rendering entries in the requested format.
+func newPrinter(w io.Writer, format string) (func(pocketlog.Entry) error, error) {
+	switch format {
+	case "text":
+		return func(e pocketlog.Entry) error {
+			_, err := fmt.Fprintln(w, e)
+			return err
+		}, nil
+	case "json":
+		encoder := json.NewEncoder(w)
+		return func(e pocketlog.Entry) error {
+			return encoder.Encode(e)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// query reads entries and prints those that match its filter.
+type query struct {
+	filter pocketlog.Filter
+	print  func(pocketlog.Entry) error
+	tail   int
+	follow bool
+
+	// entries and dated count the entries read and those carrying a timestamp, to spot logs a time range can't filter.
+	entries int
+	dated   int
+}
+
+// runFile runs the query on the file at the given path.
+func (q *query) runFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return q.run(f)
+}
+
+// run prints the matching entries of r. In follow mode, it never returns unless reading fails.
+func (q *query) run(r io.Reader) error {
+	reader := bufio.NewReader(r)
+
+	// when tailing, the last matching entries are held until the end of the input is reached.
+	var last []pocketlog.Entry
+
+	emit := func(e pocketlog.Entry) error {
+		if q.tail <= 0 {
+			return q.print(e)
+		}
+
+		last = append(last, e)
+		if len(last) > q.tail {
+			last = last[1:]
+		}
+		return nil
+	}
+
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		partial += line
+
+		switch {
+		case errors.Is(err, io.EOF):
+			if !q.follow {
+				if strings.TrimSpace(partial) != "" {
+					if err = q.handle(partial, emit); err != nil {
+						return err
+					}
+				}
+				q.warnUndated()
+				return q.flush(last)
+			}
+
+			// everything written so far has been read, keep waiting for more.
+			q.warnUndated()
+			if err = q.flush(last); err != nil {
+				return err
+			}
+			last = nil
+			q.tail = 0
+			time.Sleep(pollInterval)
+			continue
+		case err != nil:
+			return err
+		}
+
+		if err = q.handle(partial, emit); err != nil {
+			return err
+		}
+		partial = ""
+	}
+}
+
+// handle parses a line and passes it on if it matches the filter.
+// Lines that aren't log entries are reported and skipped.
+func (q *query) handle(line string, emit func(pocketlog.Entry) error) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+
+	e, err := pocketlog.ParseEntry(line)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "skipping line: %s\n", err)
+		return nil
+	}
+
+	q.entries++
+	if !e.Time.IsZero() {
+		q.dated++
+	}
+
+	if !q.filter.Match(e) {
+		return nil
+	}
+
+	return emit(e)
+}
+
+// warnUndated reports a time range that can't match anything, because none of the entries read so far carries a timestamp.
+// The warning is given once.
+func (q *query) warnUndated() {
+	if q.filter.Since.IsZero() && q.filter.Until.IsZero() || q.entries == 0 || q.dated > 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "warning: no entry carries a timestamp, -since and -until match none of them; "+
+		"write them with the pocketlog.WithTimestamps option")
+	// counting a dated entry silences the warning for the next files and polls.
+	q.dated = 1
+}
+
+// flush prints the entries held for tailing.
+func (q *query) flush(entries []pocketlog.Entry) error {
+	for _, e := range entries {
+		if err := q.print(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package pocketlog
+
+import "context"
+
+// Keys of the fields holding the trace and span identifiers of an entry.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// spanContextKey is the key of the span identifiers in a context.
+type spanContextKey struct{}
+
+// spanContext identifies the span an entry was logged in.
+type spanContext struct {
+	traceID string
+	spanID  string
+}
+
+// ContextWithSpan returns a copy of ctx carrying the identifiers of a trace and one of its spans,
+// as hex strings of 32 and 16 characters.
+// Entries logged with this context through LogfContext hold them in their TraceIDKey and SpanIDKey fields.
+func ContextWithSpan(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext{traceID: traceID, spanID: spanID})
+}
+
+// SpanFromContext returns the trace and span identifiers stored in the context by ContextWithSpan.
+func SpanFromContext(ctx context.Context) (traceID, spanID string, ok bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(spanContext)
+	return sc.traceID, sc.spanID, ok
+}
+
+// spanFields returns the fields identifying the span of the context, or nil.
+func spanFields(ctx context.Context) map[string]string {
+	traceID, spanID, ok := SpanFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return map[string]string{TraceIDKey: traceID, SpanIDKey: spanID}
+}
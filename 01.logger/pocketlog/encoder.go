@@ -0,0 +1,23 @@
+package pocketlog
+
+import "fmt"
+
+// Encoder turns an entry into the bytes written to the output of a Logger, including the line terminator, if any.
+type Encoder interface {
+	Encode(e Entry) ([]byte, error)
+}
+
+// textEncoder writes entries in the "[LEVEL] message" format.
+type textEncoder struct {
+	// timestamps prefixes each line with the RFC 3339 time of the entry, as read back by ParseEntry.
+	timestamps bool
+}
+
+// Encode implements the Encoder interface.
+func (enc textEncoder) Encode(e Entry) ([]byte, error) {
+	if enc.timestamps {
+		return []byte(e.String() + "\n"), nil
+	}
+
+	return []byte(fmt.Sprintf("%s %s\n", e.Level, e.Message)), nil
+}
@@ -0,0 +1,198 @@
+package pocketlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Entry is a log line, as read back from the output of a Logger.
+type Entry struct {
+	// Time is the moment the entry was written. It is zero when the line carries no timestamp.
+	Time    time.Time
+	Level   Level
+	Message string
+	// Fields holds the key=value pairs of a text line, or the extra keys of a JSON line.
+	Fields map[string]string
+}
+
+// keys of the JSON representation of an Entry.
+const (
+	jsonTimeKey    = "time"
+	jsonLevelKey   = "level"
+	jsonMessageKey = "message"
+)
+
+// ParseEntry reads a single log line.
+// It understands the "[LEVEL] message" text format written by a Logger, optionally prefixed
+// by an RFC 3339 timestamp, as well as JSON objects with time, level and message keys.
+func ParseEntry(line string) (Entry, error) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "{") {
+		return parseJSONEntry(line)
+	}
+
+	return parseTextEntry(line)
+}
+
+// parseTextEntry reads a line such as "2022-12-03T10:00:00Z [INFO] message".
+func parseTextEntry(line string) (Entry, error) {
+	var e Entry
+
+	if !strings.HasPrefix(line, "[") {
+		// the line might start with a timestamp
+		timestamp, rest, found := strings.Cut(line, " ")
+		if !found {
+			return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+		}
+
+		e.Time = t
+		line = strings.TrimLeft(rest, " ")
+	}
+
+	end := strings.Index(line, "]")
+	if !strings.HasPrefix(line, "[") || end == -1 {
+		return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+	}
+
+	lvl, err := ParseLevel(line[:end+1])
+	if err != nil {
+		return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+	}
+
+	e.Level = lvl
+	e.Message = strings.TrimPrefix(line[end+1:], " ")
+	e.Fields = parseFields(e.Message)
+
+	return e, nil
+}
+
+// parseFields extracts the key=value pairs of a message.
+func parseFields(message string) map[string]string {
+	var fields map[string]string
+
+	for _, word := range strings.Fields(message) {
+		key, value, found := strings.Cut(word, "=")
+		if !found || key == "" {
+			continue
+		}
+
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[key] = value
+	}
+
+	return fields
+}
+
+// parseJSONEntry reads a line such as {"time":"2022-12-03T10:00:00Z","level":"INFO","message":"message"}.
+func parseJSONEntry(line string) (Entry, error) {
+	raw := make(map[string]any)
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+	}
+
+	var e Entry
+
+	levelName, ok := raw[jsonLevelKey].(string)
+	if !ok {
+		return Entry{}, fmt.Errorf("%q: missing level: %w", line, ErrInvalidEntry)
+	}
+
+	lvl, err := ParseLevel(levelName)
+	if err != nil {
+		return Entry{}, fmt.Errorf("%q: %w", line, ErrInvalidEntry)
+	}
+	e.Level = lvl
+
+	if timestamp, ok := raw[jsonTimeKey].(string); ok {
+		e.Time, err = time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			return Entry{}, fmt.Errorf("%q: invalid time: %w", line, ErrInvalidEntry)
+		}
+	}
+
+	e.Message, _ = raw[jsonMessageKey].(string)
+
+	for key, value := range raw {
+		if key == jsonTimeKey || key == jsonLevelKey || key == jsonMessageKey {
+			continue
+		}
+
+		if e.Fields == nil {
+			e.Fields = make(map[string]string)
+		}
+		e.Fields[key] = fmt.Sprint(value)
+	}
+
+	return e, nil
+}
+
+// String implements the fmt.Stringer interface.
+// It renders the entry in the text format of a Logger, prefixed by its timestamp if it has one.
+func (e Entry) String() string {
+	if e.Time.IsZero() {
+		return fmt.Sprintf("%s %s", e.Level, e.Message)
+	}
+
+	return fmt.Sprintf("%s %s %s", e.Time.Format(time.RFC3339Nano), e.Level, e.Message)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Fields are written next to the time, level and message keys.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]any, len(e.Fields)+3)
+	for key, value := range e.Fields {
+		raw[key] = value
+	}
+
+	if !e.Time.IsZero() {
+		raw[jsonTimeKey] = e.Time.Format(time.RFC3339Nano)
+	}
+	raw[jsonLevelKey] = e.Level.name()
+	raw[jsonMessageKey] = e.Message
+
+	return json.Marshal(raw)
+}
+
+// Filter selects entries. Its zero value matches every entry.
+type Filter struct {
+	// MinLevel is the lowest level an entry must have.
+	MinLevel Level
+	// Since and Until bound the time of the entry, when they are set.
+	// Entries without a timestamp never match a time range.
+	Since time.Time
+	Until time.Time
+	// Fields lists the values an entry must hold.
+	Fields map[string]string
+}
+
+// Match tells whether the entry satisfies every criterion of the filter.
+func (f Filter) Match(e Entry) bool {
+	if e.Level < f.MinLevel {
+		return false
+	}
+
+	if !f.Since.IsZero() && (e.Time.IsZero() || e.Time.Before(f.Since)) {
+		return false
+	}
+
+	if !f.Until.IsZero() && (e.Time.IsZero() || e.Time.After(f.Until)) {
+		return false
+	}
+
+	for key, value := range f.Fields {
+		if e.Fields[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,209 @@
+package pocketlog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"pocketlog/pocketlog"
+	"testing"
+	"time"
+)
+
+func TestParseEntry(t *testing.T) {
+	type testCase struct {
+		line     string
+		expected pocketlog.Entry
+		err      error
+	}
+
+	tt := map[string]testCase{
+		"text": {
+			line:     "[INFO] " + infoMessage,
+			expected: pocketlog.Entry{Level: pocketlog.LevelInfo, Message: infoMessage},
+		},
+		"text with timestamp and fields": {
+			line: "2022-12-03T10:00:00Z [ERROR] disk full user=diana retries=3",
+			expected: pocketlog.Entry{
+				Time:    time.Date(2022, 12, 3, 10, 0, 0, 0, time.UTC),
+				Level:   pocketlog.LevelError,
+				Message: "disk full user=diana retries=3",
+				Fields:  map[string]string{"user": "diana", "retries": "3"},
+			},
+		},
+		"json": {
+			line: `{"time":"2022-12-03T10:00:00Z","level":"DEBUG","message":"` + debugMessage + `","user":"diana"}`,
+			expected: pocketlog.Entry{
+				Time:    time.Date(2022, 12, 3, 10, 0, 0, 0, time.UTC),
+				Level:   pocketlog.LevelDebug,
+				Message: debugMessage,
+				Fields:  map[string]string{"user": "diana"},
+			},
+		},
+		"unknown level": {
+			line: "[WARN] " + infoMessage,
+			err:  pocketlog.ErrInvalidEntry,
+		},
+		"no level": {
+			line: infoMessage,
+			err:  pocketlog.ErrInvalidEntry,
+		},
+		"json without level": {
+			line: `{"message":"` + infoMessage + `"}`,
+			err:  pocketlog.ErrInvalidEntry,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			e, err := pocketlog.ParseEntry(tc.line)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected err %v, got %v", tc.err, err)
+			}
+
+			if !equalEntries(e, tc.expected) {
+				t.Errorf("invalid entry, expected %+v, got %+v", tc.expected, e)
+			}
+		})
+	}
+}
+
+func TestEntry_Formats(t *testing.T) {
+	e := pocketlog.Entry{
+		Time:    time.Date(2022, 12, 3, 10, 0, 0, 0, time.UTC),
+		Level:   pocketlog.LevelInfo,
+		Message: "user=diana logged in",
+		Fields:  map[string]string{"user": "diana"},
+	}
+
+	text := "2022-12-03T10:00:00Z [INFO] user=diana logged in"
+	if e.String() != text {
+		t.Errorf("invalid text, expected %q, got %q", text, e.String())
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"level":"INFO","message":"user=diana logged in","time":"2022-12-03T10:00:00Z","user":"diana"}`
+	if string(data) != expected {
+		t.Errorf("invalid json, expected %s, got %s", expected, data)
+	}
+
+	// both formats read back to the same entry
+	for _, line := range []string{text, string(data)} {
+		parsed, err := pocketlog.ParseEntry(line)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !equalEntries(parsed, e) {
+			t.Errorf("invalid entry, expected %+v, got %+v", e, parsed)
+		}
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	noon := time.Date(2022, 12, 3, 12, 0, 0, 0, time.UTC)
+	e := pocketlog.Entry{Time: noon, Level: pocketlog.LevelInfo, Message: infoMessage, Fields: map[string]string{"user": "diana"}}
+
+	tt := map[string]struct {
+		filter   pocketlog.Filter
+		entry    pocketlog.Entry
+		expected bool
+	}{
+		"zero filter": {
+			filter:   pocketlog.Filter{},
+			entry:    e,
+			expected: true,
+		},
+		"level too low": {
+			filter:   pocketlog.Filter{MinLevel: pocketlog.LevelError},
+			entry:    e,
+			expected: false,
+		},
+		"within time range": {
+			filter:   pocketlog.Filter{Since: noon.Add(-time.Hour), Until: noon.Add(time.Hour)},
+			entry:    e,
+			expected: true,
+		},
+		"before time range": {
+			filter:   pocketlog.Filter{Since: noon.Add(time.Minute)},
+			entry:    e,
+			expected: false,
+		},
+		"no timestamp with a time range": {
+			filter:   pocketlog.Filter{Until: noon},
+			entry:    pocketlog.Entry{Level: pocketlog.LevelInfo},
+			expected: false,
+		},
+		"matching field": {
+			filter:   pocketlog.Filter{Fields: map[string]string{"user": "diana"}},
+			entry:    e,
+			expected: true,
+		},
+		"other field value": {
+			filter:   pocketlog.Filter{Fields: map[string]string{"user": "donia"}},
+			entry:    e,
+			expected: false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.filter.Match(tc.entry); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range []string{"error", "ERROR", "[ERROR]"} {
+		lvl, err := pocketlog.ParseLevel(name)
+		if err != nil || lvl != pocketlog.LevelError {
+			t.Errorf("%q: expected %v, got %v (err %v)", name, pocketlog.LevelError, lvl, err)
+		}
+	}
+
+	_, err := pocketlog.ParseLevel("warning")
+	if !errors.Is(err, pocketlog.ErrUnknownLevel) {
+		t.Errorf("expected err %v, got %v", pocketlog.ErrUnknownLevel, err)
+	}
+}
+
+func equalEntries(a, b pocketlog.Entry) bool {
+	if !a.Time.Equal(b.Time) || a.Level != b.Level || a.Message != b.Message || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+
+	for key, value := range a.Fields {
+		if b.Fields[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestLogger_WithTimestamps(t *testing.T) {
+	noon := time.Date(2022, 12, 3, 12, 0, 0, 0, time.UTC)
+	tw := &testWriter{}
+
+	logger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithOutput(tw), pocketlog.WithTimestamps(),
+		pocketlog.WithClock(func() time.Time { return noon }))
+	logger.Infof(infoMessage)
+
+	expected := "2022-12-03T12:00:00Z [INFO] " + infoMessage + "\n"
+	if tw.contents != expected {
+		t.Fatalf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+
+	e, err := pocketlog.ParseEntry(tw.contents)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	filter := pocketlog.Filter{Since: noon.Add(-time.Hour), Until: noon.Add(time.Hour)}
+	if !filter.Match(e) {
+		t.Errorf("expected %+v to match the time range", e)
+	}
+}
@@ -0,0 +1,30 @@
+package pocketlog
+
+// ErrUnknownLevel is returned when a level name doesn't match any Level.
+const ErrUnknownLevel = logError("unknown level")
+
+// ErrInvalidEntry is returned when a line can't be parsed as a log entry.
+const ErrInvalidEntry = logError("invalid log entry")
+
+// ErrWriteTimeout is reported when the output takes longer than the write timeout to accept an entry.
+const ErrWriteTimeout = logError("write to output timed out")
+
+// ErrOutputStalled is reported when an entry is logged while a previous write to the output still hasn't returned.
+const ErrOutputStalled = logError("output is stalled by a previous write")
+
+// ErrOutputPanicked is reported when the output panics while writing an entry.
+const ErrOutputPanicked = logError("output panicked")
+
+// ErrExportQueueFull is returned when an entry is written to an OTLPExporter whose queue is full.
+const ErrExportQueueFull = logError("export queue is full")
+
+// ErrExporterClosed is returned when an entry is written to an OTLPExporter after Close.
+const ErrExporterClosed = logError("exporter is closed")
+
+// logError defines a sentinel error.
+type logError string
+
+// Error is the implementation of the error interface by logError
+func (e logError) Error() string {
+	return string(e)
+}
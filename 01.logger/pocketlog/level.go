@@ -1,5 +1,10 @@
 package pocketlog
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Level represents an available logging level.
 type Level byte
 
@@ -26,3 +31,23 @@ func (lvl Level) String() string {
 		return ""
 	}
 }
+
+// name returns the level without its decorations, such as INFO.
+func (lvl Level) name() string {
+	return strings.Trim(lvl.String(), "[]")
+}
+
+// ParseLevel returns the Level matching the given name.
+// Both the bare name and its decorated form are accepted, regardless of case: "info", "INFO" and "[INFO]" are all LevelInfo.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToUpper(strings.Trim(strings.TrimSpace(name), "[]")) {
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "ERROR":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("%q: %w", name, ErrUnknownLevel)
+	}
+}
@@ -1,9 +1,13 @@
 package pocketlog
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Logger is used to log information.
@@ -11,6 +15,56 @@ type Logger struct {
 	threshold        Level
 	output           io.Writer
 	maxMessageLength int
+	// encoder turns entries into the bytes written to the output.
+	encoder Encoder
+	// now returns the time of new entries.
+	now func() time.Time
+
+	// mutex protects the output, which is also written to when a deduplication window expires.
+	mutex sync.Mutex
+	// dedupWindow is how long identical consecutive entries are collapsed. Zero disables deduplication.
+	dedupWindow time.Duration
+	// repeated is the entry currently being deduplicated, if any.
+	repeated *repetition
+
+	// onWriteError is notified of every entry the output failed to accept.
+	onWriteError func(error)
+	// fallback receives the entries the output failed to accept.
+	fallback io.Writer
+	// writeTimeout is how long a write to the output may take. Zero waits forever.
+	writeTimeout time.Duration
+	// stalled and fallbackStalled are set while a write that timed out hasn't returned, to the output or the fallback.
+	stalled         atomic.Bool
+	fallbackStalled atomic.Bool
+	// failedWrites counts the entries the output failed to write, lostEntries those the fallback couldn't save.
+	failedWrites uint64
+	lostEntries  uint64
+
+	// recorder keeps the entries below the threshold, if the flight recorder is enabled.
+	recorder *flightRecorder
+}
+
+// repetition counts the occurrences of an entry already written to the output.
+type repetition struct {
+	entry Entry
+	count int
+	// timer closes the window, it is stopped when a different message closes it first.
+	timer *time.Timer
+}
+
+// repeats tells whether other is a repetition of the entry: the same level, message and fields, at any time.
+func (e Entry) repeats(other Entry) bool {
+	if e.Level != other.Level || e.Message != other.Message || len(e.Fields) != len(other.Fields) {
+		return false
+	}
+
+	for key, value := range e.Fields {
+		if otherValue, ok := other.Fields[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	return true
 }
 
 // New returns you a logger, ready to logf at the required threshold.
@@ -18,7 +72,7 @@ type Logger struct {
 // The default output is Stdout.
 // There is no maxMessageLength character limit
 func New(threshold Level, opts ...Option) *Logger {
-	l := &Logger{threshold: threshold, output: os.Stdout, maxMessageLength: 0}
+	l := &Logger{threshold: threshold, output: os.Stdout, maxMessageLength: 0, encoder: textEncoder{}, now: time.Now}
 
 	for _, configFunc := range opts {
 		configFunc(l)
@@ -44,19 +98,95 @@ func (l *Logger) Errorf(format string, args ...any) {
 
 // Logf formats and prints a message if the log level is high enough
 func (l *Logger) Logf(lvl Level, format string, args ...any) {
+	l.LogfContext(context.Background(), lvl, format, args...)
+}
+
+// LogfContext formats and prints a message if the log level is high enough.
+// The trace and span identifiers stored in the context by ContextWithSpan are attached to the entry.
+func (l *Logger) LogfContext(ctx context.Context, lvl Level, format string, args ...any) {
 	if l.threshold > lvl {
+		if l.recorder != nil {
+			l.record(l.entry(ctx, lvl, format, args...))
+		}
 		return
 	}
-	l.logf(lvl, format, args...)
+	l.logf(l.entry(ctx, lvl, format, args...))
 }
 
-// logf prints the message to the output
-// Add decorations here, if any
+// entry builds the entry of a message.
 // Text longer than maxMessageLength will be trimmed off
-func (l *Logger) logf(level Level, format string, args ...any) {
+func (l *Logger) entry(ctx context.Context, level Level, format string, args ...any) Entry {
 	message := fmt.Sprintf(format, args...)
 	if l.maxMessageLength != 0 && len([]rune(message)) > l.maxMessageLength {
 		message = string([]rune(message)[:l.maxMessageLength])
 	}
-	_, _ = fmt.Fprintf(l.output, "%s %s\n", level, message)
+
+	return Entry{Time: l.now(), Level: level, Message: message, Fields: spanFields(ctx)}
+}
+
+// logf prints the entry to the output
+// Add decorations here, if any
+func (l *Logger) logf(e Entry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.recorder != nil && e.Level >= l.recorder.trigger {
+		l.dump()
+	}
+
+	if l.dedupWindow > 0 {
+		if r := l.repeated; r != nil && r.entry.repeats(e) {
+			r.count++
+			return
+		}
+
+		l.flushRepetition()
+		l.startRepetition(e)
+	}
+
+	l.write(e)
+}
+
+// Flush writes the summary of the entry currently being deduplicated, if it was repeated.
+// Call it before exiting, so that the last repetitions are not lost.
+func (l *Logger) Flush() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.flushRepetition()
+}
+
+// startRepetition opens a deduplication window for the entry.
+// When the window expires, the repetitions are summarised and the next identical message is written again.
+func (l *Logger) startRepetition(e Entry) {
+	r := &repetition{entry: e}
+	l.repeated = r
+
+	r.timer = time.AfterFunc(l.dedupWindow, func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		// the window may have been closed by a different message already.
+		if l.repeated == r {
+			l.flushRepetition()
+		}
+	})
+}
+
+// flushRepetition closes the current deduplication window, writing a summary if the entry was repeated.
+// The caller must hold the mutex.
+func (l *Logger) flushRepetition() {
+	r := l.repeated
+	if r == nil {
+		return
+	}
+	l.repeated = nil
+	r.timer.Stop()
+
+	if r.count > 0 {
+		summary := r.entry
+		summary.Time = l.now()
+		summary.Message = fmt.Sprintf("last message repeated %d times", r.count)
+		l.write(summary)
+	}
 }
@@ -1,8 +1,11 @@
 package pocketlog_test
 
 import (
+	"context"
 	"pocketlog/pocketlog"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -120,6 +123,64 @@ func TestLogger_LogfMaxLength(t *testing.T) {
 	}
 }
 
+func TestLogger_Deduplication(t *testing.T) {
+	tw := &testWriter{}
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithOutput(tw), pocketlog.WithDeduplication(time.Hour))
+
+	for i := 0; i < 4; i++ {
+		testLogger.Errorf(errorMessage)
+	}
+	testLogger.Infof(infoMessage)
+	testLogger.Errorf(errorMessage)
+	testLogger.Errorf(errorMessage)
+	testLogger.Flush()
+
+	expected := "[ERROR] " + errorMessage + "\n" +
+		"[ERROR] last message repeated 3 times\n" +
+		"[INFO] " + infoMessage + "\n" +
+		"[ERROR] " + errorMessage + "\n" +
+		"[ERROR] last message repeated 1 times\n"
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
+
+func TestLogger_DeduplicationWindowExpiry(t *testing.T) {
+	tw := &testWriter{}
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithOutput(tw), pocketlog.WithDeduplication(10*time.Millisecond))
+
+	testLogger.Errorf(errorMessage)
+	testLogger.Errorf(errorMessage)
+	time.Sleep(50 * time.Millisecond)
+	testLogger.Errorf(errorMessage)
+	// Flush synchronises with the logger, there is nothing left to summarise.
+	testLogger.Flush()
+
+	expected := "[ERROR] " + errorMessage + "\n" +
+		"[ERROR] last message repeated 1 times\n" +
+		"[ERROR] " + errorMessage + "\n"
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
+
+func TestLogger_DeduplicationFields(t *testing.T) {
+	tw := &testWriter{}
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithOutput(tw), pocketlog.WithDeduplication(time.Hour),
+		pocketlog.WithEncoder(pocketlog.OTLPEncoder{}))
+
+	first := pocketlog.ContextWithSpan(context.Background(), traceID, spanID)
+	second := pocketlog.ContextWithSpan(context.Background(), traceID, "b7ad6b7169203331")
+	testLogger.LogfContext(first, pocketlog.LevelError, errorMessage)
+	testLogger.LogfContext(second, pocketlog.LevelError, errorMessage)
+	testLogger.LogfContext(second, pocketlog.LevelError, errorMessage)
+	testLogger.Flush()
+
+	if lines := strings.Count(tw.contents, "\n"); lines != 3 {
+		t.Errorf("expected the entries of both spans and a summary, got %q", tw.contents)
+	}
+}
+
 type testWriter struct {
 	contents string
 }
@@ -1,6 +1,9 @@
 package pocketlog
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // Option defines a functional option to our logger.
 type Option func(*Logger)
@@ -16,3 +19,74 @@ func WithMaxLength(maxLength int) Option {
 		l.maxMessageLength = maxLength
 	}
 }
+
+// WithDeduplication collapses consecutive identical entries logged within the window.
+// The first entry is written as usual, its repetitions are replaced by a single
+// "last message repeated N times" entry, written when the window expires or when a different entry is logged.
+func WithDeduplication(window time.Duration) Option {
+	return func(l *Logger) {
+		l.dedupWindow = window
+	}
+}
+
+// WithErrorHandler registers a function called with the error of every entry the output failed to write.
+// The handler is called while the logger is locked: it must not log through the same Logger.
+func WithErrorHandler(handler func(error)) Option {
+	return func(l *Logger) {
+		l.onWriteError = handler
+	}
+}
+
+// WithFallback sets a writer, such as os.Stderr, receiving the entries the output failed to write.
+func WithFallback(fallback io.Writer) Option {
+	return func(l *Logger) {
+		l.fallback = fallback
+	}
+}
+
+// WithWriteTimeout bounds the time a write to the output may take.
+// Past the timeout, the entry is treated as failed and the caller resumes.
+// Until the slow write returns, later entries go straight to the fallback.
+// The fallback is bounded by the same timeout.
+// A write that timed out can't be cancelled: if it eventually succeeds, the entry appears in both the output and the fallback.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(l *Logger) {
+		l.writeTimeout = timeout
+	}
+}
+
+// WithFlightRecorder keeps the last size entries below the threshold in memory instead of discarding them.
+// They are written to the output, oldest first, right before the next entry at or above the trigger level,
+// or when Dump is called. The trigger has no effect below the threshold of the logger.
+func WithFlightRecorder(size int, trigger Level) Option {
+	return func(l *Logger) {
+		if size <= 0 {
+			l.recorder = nil
+			return
+		}
+		l.recorder = &flightRecorder{trigger: trigger, entries: make([]Entry, 0, size)}
+	}
+}
+
+// WithEncoder sets the format of the entries written to the output.
+// The default is the "[LEVEL] message" text format.
+func WithEncoder(encoder Encoder) Option {
+	return func(l *Logger) {
+		l.encoder = encoder
+	}
+}
+
+// WithTimestamps writes the time of each entry in front of it, in the "2022-12-03T10:00:00Z [INFO] message" format,
+// so that the entries can be filtered by time when they are read back. It replaces the encoder with the text one.
+func WithTimestamps() Option {
+	return func(l *Logger) {
+		l.encoder = textEncoder{timestamps: true}
+	}
+}
+
+// WithClock sets the function giving the time of the entries. The default is time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(l *Logger) {
+		l.now = now
+	}
+}
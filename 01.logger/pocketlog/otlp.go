@@ -0,0 +1,406 @@
+package pocketlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OTLPEncoder writes each entry as an OTLP-JSON ExportLogsServiceRequest holding a single log record,
+// followed by a newline, as expected by OpenTelemetry collectors.
+type OTLPEncoder struct {
+	// Resource lists the attributes of the entity producing the logs, such as "service.name".
+	Resource map[string]string
+	// Scope is the name of the instrumentation scope. It defaults to "pocketlog".
+	Scope string
+}
+
+// OTLP severity numbers, as defined by the OpenTelemetry log data model.
+const (
+	otlpSeverityDebug = 5
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+)
+
+// severityNumber maps a Level to its OTLP severity number.
+func severityNumber(lvl Level) int {
+	switch lvl {
+	case LevelDebug:
+		return otlpSeverityDebug
+	case LevelInfo:
+		return otlpSeverityInfo
+	case LevelError:
+		return otlpSeverityError
+	default:
+		// SEVERITY_NUMBER_UNSPECIFIED
+		return 0
+	}
+}
+
+// The types below mirror the JSON encoding of the OTLP logs protocol.
+type (
+	otlpRequest struct {
+		ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+	}
+
+	otlpResourceLogs struct {
+		Resource  otlpResource    `json:"resource"`
+		ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes,omitempty"`
+	}
+
+	otlpScopeLogs struct {
+		Scope      otlpScope       `json:"scope"`
+		LogRecords []otlpLogRecord `json:"logRecords"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpLogRecord struct {
+		TimeUnixNano         string          `json:"timeUnixNano,omitempty"`
+		ObservedTimeUnixNano string          `json:"observedTimeUnixNano,omitempty"`
+		SeverityNumber       int             `json:"severityNumber"`
+		SeverityText         string          `json:"severityText"`
+		Body                 otlpValue       `json:"body"`
+		Attributes           []otlpAttribute `json:"attributes,omitempty"`
+		TraceID              string          `json:"traceId,omitempty"`
+		SpanID               string          `json:"spanId,omitempty"`
+	}
+
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+
+	otlpValue struct {
+		StringValue string `json:"stringValue"`
+	}
+)
+
+// Encode implements the Encoder interface.
+// The trace and span identifiers are read from the TraceIDKey and SpanIDKey fields, the other fields become attributes.
+func (enc OTLPEncoder) Encode(e Entry) ([]byte, error) {
+	record := otlpLogRecord{
+		SeverityNumber: severityNumber(e.Level),
+		SeverityText:   e.Level.name(),
+		Body:           otlpValue{StringValue: e.Message},
+	}
+
+	// invalid identifiers would make the whole request invalid, they are kept as attributes instead.
+	if id := e.Fields[TraceIDKey]; isHexID(id, 32) {
+		record.TraceID = id
+	}
+	if id := e.Fields[SpanIDKey]; isHexID(id, 16) {
+		record.SpanID = id
+	}
+
+	if !e.Time.IsZero() {
+		record.TimeUnixNano = strconv.FormatInt(e.Time.UnixNano(), 10)
+		record.ObservedTimeUnixNano = record.TimeUnixNano
+	}
+
+	for _, attr := range otlpAttributes(e.Fields) {
+		if (attr.Key != TraceIDKey || record.TraceID == "") && (attr.Key != SpanIDKey || record.SpanID == "") {
+			record.Attributes = append(record.Attributes, attr)
+		}
+	}
+
+	scope := enc.Scope
+	if scope == "" {
+		scope = "pocketlog"
+	}
+
+	request := otlpRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource: otlpResource{Attributes: otlpAttributes(enc.Resource)},
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpScope{Name: scope},
+			LogRecords: []otlpLogRecord{record},
+		}},
+	}}}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// isHexID tells whether id is a valid trace or span identifier: length lowercase hex characters, not all zero.
+func isHexID(id string, length int) bool {
+	if len(id) != length || strings.Trim(id, "0") == "" {
+		return false
+	}
+
+	for _, r := range id {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// otlpAttributes converts a map into attributes sorted by key.
+func otlpAttributes(values map[string]string) []otlpAttribute {
+	attributes := make([]otlpAttribute, 0, len(values))
+	for key, value := range values {
+		attributes = append(attributes, otlpAttribute{Key: key, Value: otlpValue{StringValue: value}})
+	}
+
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+
+	return attributes
+}
+
+// Defaults of the batches sent by an OTLPExporter.
+const (
+	defaultExportBatchSize = 100
+	defaultExportInterval  = time.Second
+	// defaultExportTimeout bounds the requests of the default client, so that Close returns even if the collector hangs.
+	defaultExportTimeout = 10 * time.Second
+	// exportQueueSize is the number of entries waiting to be exported. Writes fail once it's full.
+	exportQueueSize = 1024
+)
+
+// OTLPExporter is an output sending what is written to it to an OTLP/HTTP logs endpoint.
+// Use it with an OTLPEncoder, so that every write is a complete export request.
+//
+// Writes only queue the entries: they are sent in batches by a goroutine of the exporter,
+// so that a slow collector doesn't block the logger. A batch is sent once it's full, or after the export interval.
+// As export failures happen after the write returned, the entries don't reach the fallback of the logger:
+// use WithExportErrorHandler to be notified. Call Close before exiting, to send the last entries.
+type OTLPExporter struct {
+	endpoint  string
+	client    *http.Client
+	batchSize int
+	interval  time.Duration
+	onError   func(error)
+
+	// mutex protects closed, so that nothing is queued once the queue is closed.
+	mutex  sync.Mutex
+	closed bool
+	queue  chan []byte
+	// flushes receives the requests of Flush, each closed once the entries queued before it are sent.
+	flushes chan chan struct{}
+	// done is closed once the goroutine has sent the last batch.
+	done chan struct{}
+}
+
+// ExporterOption defines a functional option to an OTLPExporter.
+type ExporterOption func(*OTLPExporter)
+
+// WithExportBatch sets the maximum number of entries sent in a request, and how long an entry may wait to be sent.
+// The defaults are 100 entries and a second. They also apply in place of a size below 1, or an interval that isn't positive.
+func WithExportBatch(size int, interval time.Duration) ExporterOption {
+	return func(exp *OTLPExporter) {
+		exp.batchSize = size
+		exp.interval = interval
+	}
+}
+
+// WithExportErrorHandler registers a function called with the error of every batch the exporter failed to send.
+func WithExportErrorHandler(handler func(error)) ExporterOption {
+	return func(exp *OTLPExporter) {
+		exp.onError = handler
+	}
+}
+
+// NewOTLPExporter returns an exporter posting to the endpoint, such as "http://localhost:4318/v1/logs".
+// A nil client means a client whose requests time out after 10 seconds.
+func NewOTLPExporter(endpoint string, client *http.Client, opts ...ExporterOption) *OTLPExporter {
+	if client == nil {
+		client = &http.Client{Timeout: defaultExportTimeout}
+	}
+
+	exp := &OTLPExporter{
+		endpoint:  endpoint,
+		client:    client,
+		batchSize: defaultExportBatchSize,
+		interval:  defaultExportInterval,
+		queue:     make(chan []byte, exportQueueSize),
+		flushes:   make(chan chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(exp)
+	}
+
+	if exp.batchSize < 1 {
+		exp.batchSize = defaultExportBatchSize
+	}
+	if exp.interval <= 0 {
+		exp.interval = defaultExportInterval
+	}
+
+	go exp.run()
+
+	return exp
+}
+
+// Write implements the io.Writer interface. It queues p, an export request holding a single entry.
+// It fails with ErrExportQueueFull if the collector can't keep up, and with ErrExporterClosed after Close.
+func (exp *OTLPExporter) Write(p []byte) (int, error) {
+	exp.mutex.Lock()
+	defer exp.mutex.Unlock()
+
+	if exp.closed {
+		return 0, ErrExporterClosed
+	}
+
+	// the caller may reuse p once Write returns.
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	select {
+	case exp.queue <- data:
+		return len(p), nil
+	default:
+		return 0, ErrExportQueueFull
+	}
+}
+
+// Flush sends the entries written so far, and waits for the request to complete.
+// Writes aren't blocked meanwhile, they keep being queued.
+func (exp *OTLPExporter) Flush() {
+	flushed := make(chan struct{})
+
+	select {
+	case exp.flushes <- flushed:
+		<-flushed
+	case <-exp.done:
+		// Close already sent everything.
+	}
+}
+
+// Close sends the pending entries and stops the exporter. Later writes fail.
+func (exp *OTLPExporter) Close() error {
+	exp.mutex.Lock()
+	if !exp.closed {
+		exp.closed = true
+		close(exp.queue)
+	}
+	exp.mutex.Unlock()
+
+	<-exp.done
+	return nil
+}
+
+// run sends the queued entries in batches, until the exporter is closed.
+func (exp *OTLPExporter) run() {
+	defer close(exp.done)
+
+	ticker := time.NewTicker(exp.interval)
+	defer ticker.Stop()
+
+	var batch [][]byte
+	for {
+		select {
+		case data, ok := <-exp.queue:
+			if !ok {
+				exp.send(batch)
+				return
+			}
+
+			batch = append(batch, data)
+			if len(batch) >= exp.batchSize {
+				exp.send(batch)
+				batch = nil
+			}
+		case flushed := <-exp.flushes:
+			// the entries written before the flush are already queued.
+			var open bool
+			batch, open = exp.drain(batch)
+			for len(batch) > exp.batchSize {
+				exp.send(batch[:exp.batchSize])
+				batch = batch[exp.batchSize:]
+			}
+			exp.send(batch)
+			batch = nil
+			close(flushed)
+			if !open {
+				return
+			}
+		case <-ticker.C:
+			exp.send(batch)
+			batch = nil
+		}
+	}
+}
+
+// drain appends the queued entries to the batch. It returns false if the queue is closed.
+func (exp *OTLPExporter) drain(batch [][]byte) ([][]byte, bool) {
+	for {
+		select {
+		case data, ok := <-exp.queue:
+			if !ok {
+				return batch, false
+			}
+			batch = append(batch, data)
+		default:
+			return batch, true
+		}
+	}
+}
+
+// send merges the export requests of the batch into a single one, and posts it.
+func (exp *OTLPExporter) send(batch [][]byte) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var merged otlpRequest
+	for _, data := range batch {
+		var request otlpRequest
+		err := json.Unmarshal(data, &request)
+		if err != nil {
+			exp.fail(fmt.Errorf("unable to export logs: invalid export request: %w", err))
+			continue
+		}
+		merged.ResourceLogs = append(merged.ResourceLogs, request.ResourceLogs...)
+	}
+
+	if len(merged.ResourceLogs) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(merged)
+	if err != nil {
+		exp.fail(fmt.Errorf("unable to export logs: %w", err))
+		return
+	}
+
+	resp, err := exp.client.Post(exp.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		exp.fail(fmt.Errorf("unable to export logs: %w", err))
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		exp.fail(fmt.Errorf("unable to export logs: %s", resp.Status))
+	}
+}
+
+// fail reports an export error to the handler, if any.
+func (exp *OTLPExporter) fail(err error) {
+	if exp.onError != nil {
+		exp.onError(err)
+	}
+}
@@ -0,0 +1,262 @@
+package pocketlog_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pocketlog/pocketlog"
+	"testing"
+	"time"
+)
+
+const (
+	traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID  = "00f067aa0ba902b7"
+)
+
+func TestOTLPEncoder_Encode(t *testing.T) {
+	enc := pocketlog.OTLPEncoder{Resource: map[string]string{"service.name": "gordle"}}
+
+	data, err := enc.Encode(pocketlog.Entry{
+		Time:    time.Unix(1670061600, 0),
+		Level:   pocketlog.LevelError,
+		Message: errorMessage,
+		Fields:  map[string]string{pocketlog.TraceIDKey: traceID, pocketlog.SpanIDKey: spanID, "user": "diana"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"gordle"}}]},` +
+		`"scopeLogs":[{"scope":{"name":"pocketlog"},"logRecords":[{` +
+		`"timeUnixNano":"1670061600000000000","observedTimeUnixNano":"1670061600000000000",` +
+		`"severityNumber":17,"severityText":"ERROR","body":{"stringValue":"` + errorMessage + `"},` +
+		`"attributes":[{"key":"user","value":{"stringValue":"diana"}}],` +
+		`"traceId":"` + traceID + `","spanId":"` + spanID + `"}]}]}]}` + "\n"
+	if string(data) != expected {
+		t.Errorf("invalid encoding, expected\n%s\ngot\n%s", expected, data)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/logs" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer receiver.Close()
+
+	exporter := pocketlog.NewOTLPExporter(receiver.URL+"/v1/logs", receiver.Client())
+	defer exporter.Close()
+
+	testLogger := pocketlog.New(pocketlog.LevelInfo,
+		pocketlog.WithEncoder(pocketlog.OTLPEncoder{}),
+		pocketlog.WithOutput(exporter),
+	)
+
+	ctx := pocketlog.ContextWithSpan(context.Background(), traceID, spanID)
+	testLogger.LogfContext(ctx, pocketlog.LevelInfo, infoMessage)
+	testLogger.Debugf(debugMessage)
+	exporter.Flush()
+
+	if testLogger.FailedWrites() != 0 {
+		t.Fatalf("expected no failed write, got %d", testLogger.FailedWrites())
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("expected a single request, got %d", len(received))
+	}
+
+	var request struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					SeverityNumber int    `json:"severityNumber"`
+					TraceID        string `json:"traceId"`
+					SpanID         string `json:"spanId"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	if err := json.Unmarshal(<-received, &request); err != nil {
+		t.Fatalf("invalid request body: %s", err)
+	}
+
+	record := request.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	if record.SeverityNumber != 9 || record.TraceID != traceID || record.SpanID != spanID || record.Body.StringValue != infoMessage {
+		t.Errorf("invalid log record %+v", record)
+	}
+}
+
+func TestOTLPExporter_Batch(t *testing.T) {
+	received := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer receiver.Close()
+
+	exporter := pocketlog.NewOTLPExporter(receiver.URL, receiver.Client(), pocketlog.WithExportBatch(2, time.Hour))
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithEncoder(pocketlog.OTLPEncoder{}), pocketlog.WithOutput(exporter))
+
+	for i := 0; i < 3; i++ {
+		testLogger.Infof("%s %d", infoMessage, i)
+	}
+	// the third entry is sent when the exporter is closed.
+	_ = exporter.Close()
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(received))
+	}
+
+	for _, expected := range []int{2, 1} {
+		var request struct {
+			ResourceLogs []json.RawMessage `json:"resourceLogs"`
+		}
+		if err := json.Unmarshal(<-received, &request); err != nil {
+			t.Fatalf("invalid request body: %s", err)
+		}
+		if len(request.ResourceLogs) != expected {
+			t.Errorf("expected %d entries in the request, got %d", expected, len(request.ResourceLogs))
+		}
+	}
+
+	if _, err := exporter.Write([]byte("{}")); !errors.Is(err, pocketlog.ErrExporterClosed) {
+		t.Errorf("expected %v, got %v", pocketlog.ErrExporterClosed, err)
+	}
+}
+
+func TestOTLPExporter_InvalidBatch(t *testing.T) {
+	received := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer receiver.Close()
+
+	// both values fall back to the defaults instead of stopping the exporter.
+	exporter := pocketlog.NewOTLPExporter(receiver.URL, receiver.Client(), pocketlog.WithExportBatch(0, 0))
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithEncoder(pocketlog.OTLPEncoder{}), pocketlog.WithOutput(exporter))
+
+	testLogger.Infof(infoMessage)
+	testLogger.Errorf(errorMessage)
+	_ = exporter.Close()
+
+	if len(received) != 1 {
+		t.Errorf("expected a single request, got %d", len(received))
+	}
+}
+
+func TestOTLPExporter_SlowCollector(t *testing.T) {
+	unblock := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer receiver.Close()
+	defer close(unblock)
+
+	exporter := pocketlog.NewOTLPExporter(receiver.URL, receiver.Client(), pocketlog.WithExportBatch(1, time.Hour))
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithEncoder(pocketlog.OTLPEncoder{}), pocketlog.WithOutput(exporter))
+
+	logged := make(chan struct{})
+	go func() {
+		testLogger.Infof(infoMessage)
+		testLogger.Errorf(errorMessage)
+		close(logged)
+	}()
+
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the slow collector not to block the logger")
+	}
+}
+
+func TestOTLPExporter_Failure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	var reported []error
+	exporter := pocketlog.NewOTLPExporter(receiver.URL, receiver.Client(),
+		pocketlog.WithExportErrorHandler(func(err error) { reported = append(reported, err) }))
+	defer exporter.Close()
+
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithEncoder(pocketlog.OTLPEncoder{}), pocketlog.WithOutput(exporter))
+	testLogger.Infof(infoMessage)
+	exporter.Flush()
+
+	if len(reported) != 1 {
+		t.Errorf("expected the failed export to be reported, got %v", reported)
+	}
+}
+
+func TestOTLPEncoder_InvalidIDs(t *testing.T) {
+	data, err := pocketlog.OTLPEncoder{}.Encode(pocketlog.Entry{
+		Level:   pocketlog.LevelInfo,
+		Message: infoMessage,
+		Fields:  map[string]string{pocketlog.TraceIDKey: "not-a-trace", pocketlog.SpanIDKey: spanID},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{"name":"pocketlog"},"logRecords":[{` +
+		`"severityNumber":9,"severityText":"INFO","body":{"stringValue":"` + infoMessage + `"},` +
+		`"attributes":[{"key":"trace_id","value":{"stringValue":"not-a-trace"}}],"spanId":"` + spanID + `"}]}]}]}` + "\n"
+	if string(data) != expected {
+		t.Errorf("invalid encoding, expected\n%s\ngot\n%s", expected, data)
+	}
+}
+
+func TestOTLPExporter_FlushHungCollector(t *testing.T) {
+	requested := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case requested <- struct{}{}:
+		default:
+		}
+		<-unblock
+	}))
+	defer receiver.Close()
+	defer close(unblock)
+
+	exporter := pocketlog.NewOTLPExporter(receiver.URL, receiver.Client(), pocketlog.WithExportBatch(1, time.Hour))
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithEncoder(pocketlog.OTLPEncoder{}), pocketlog.WithOutput(exporter))
+
+	// the first entry hangs the exporter, the next ones fill its queue.
+	testLogger.Infof(infoMessage)
+	<-requested
+	for i := 0; i < 2000; i++ {
+		testLogger.Infof(infoMessage)
+	}
+
+	go exporter.Flush()
+	// leaves the flush the time to start waiting.
+	time.Sleep(50 * time.Millisecond)
+
+	logged := make(chan struct{})
+	go func() {
+		testLogger.Errorf(errorMessage)
+		close(logged)
+	}()
+
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatalf("expected a pending flush not to block the logger")
+	}
+}
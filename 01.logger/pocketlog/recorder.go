@@ -0,0 +1,65 @@
+package pocketlog
+
+// flightRecorder is a ring buffer holding the latest entries below the threshold.
+type flightRecorder struct {
+	// trigger is the level of the entries causing the recorder to be dumped.
+	trigger Level
+	// entries holds at most cap(entries) entries. Once full, next is the position of the oldest one.
+	entries []Entry
+	next    int
+}
+
+// add stores an entry, overwriting the oldest one if the recorder is full.
+func (fr *flightRecorder) add(e Entry) {
+	if len(fr.entries) < cap(fr.entries) {
+		fr.entries = append(fr.entries, e)
+		return
+	}
+
+	fr.entries[fr.next] = e
+	fr.next = (fr.next + 1) % len(fr.entries)
+}
+
+// drain returns the stored entries, oldest first, and empties the recorder.
+func (fr *flightRecorder) drain() []Entry {
+	drained := make([]Entry, 0, len(fr.entries))
+	drained = append(drained, fr.entries[fr.next:]...)
+	drained = append(drained, fr.entries[:fr.next]...)
+
+	fr.entries = fr.entries[:0]
+	fr.next = 0
+
+	return drained
+}
+
+// record stores an entry below the threshold in the flight recorder.
+func (l *Logger) record(e Entry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.recorder.add(e)
+}
+
+// Dump writes the entries held by the flight recorder to the output, oldest first, and empties it.
+// It does nothing if the flight recorder isn't enabled.
+func (l *Logger) Dump() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.dump()
+}
+
+// dump empties the flight recorder into the output.
+// The caller must hold the mutex.
+func (l *Logger) dump() {
+	if l.recorder == nil || len(l.recorder.entries) == 0 {
+		return
+	}
+
+	// the pending repetition happened before the recorded entries: summarise it first.
+	l.flushRepetition()
+
+	for _, e := range l.recorder.drain() {
+		l.write(e)
+	}
+}
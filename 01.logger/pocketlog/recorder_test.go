@@ -0,0 +1,42 @@
+package pocketlog_test
+
+import (
+	"pocketlog/pocketlog"
+	"testing"
+)
+
+func TestLogger_FlightRecorder(t *testing.T) {
+	tw := &testWriter{}
+	testLogger := pocketlog.New(pocketlog.LevelError, pocketlog.WithOutput(tw), pocketlog.WithFlightRecorder(2, pocketlog.LevelError))
+
+	testLogger.Debugf("dropped, the recorder only holds 2 entries")
+	testLogger.Debugf(debugMessage)
+	testLogger.Infof(infoMessage)
+
+	if tw.contents != "" {
+		t.Fatalf("expected nothing before the trigger, got %q", tw.contents)
+	}
+
+	testLogger.Errorf(errorMessage)
+	testLogger.Errorf(errorMessage)
+
+	expected := "[DEBUG] " + debugMessage + "\n" + "[INFO] " + infoMessage + "\n" +
+		"[ERROR] " + errorMessage + "\n" + "[ERROR] " + errorMessage + "\n"
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
+
+func TestLogger_Dump(t *testing.T) {
+	tw := &testWriter{}
+	testLogger := pocketlog.New(pocketlog.LevelError, pocketlog.WithOutput(tw), pocketlog.WithFlightRecorder(10, pocketlog.LevelError))
+
+	testLogger.Infof(infoMessage)
+	testLogger.Dump()
+	testLogger.Dump()
+
+	expected := "[INFO] " + infoMessage + "\n"
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
@@ -0,0 +1,97 @@
+package pocketlog
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// write prints a single entry to the output.
+// If the output fails, the error handler is notified and the entry is sent to the fallback.
+// The caller must hold the mutex.
+func (l *Logger) write(e Entry) {
+	line, err := l.encoder.Encode(e)
+	if err != nil {
+		// the fallback still deserves a readable entry.
+		line, _ = textEncoder{}.Encode(e)
+		err = fmt.Errorf("unable to encode entry: %w", err)
+	} else {
+		err = l.writeWithTimeout(l.output, &l.stalled, line)
+	}
+
+	if err == nil {
+		return
+	}
+
+	l.failedWrites++
+
+	if l.onWriteError != nil {
+		l.onWriteError(err)
+	}
+
+	if l.fallback == nil || l.writeWithTimeout(l.fallback, &l.fallbackStalled, line) != nil {
+		l.lostEntries++
+	}
+}
+
+// writeWithTimeout writes the line to w, giving up after the write timeout, if any.
+// stalled tracks the write that timed out on w, until it returns: the writer isn't given more lines meanwhile.
+// A write that timed out isn't cancelled, the line still reaches w if it eventually returns.
+func (l *Logger) writeWithTimeout(w io.Writer, stalled *atomic.Bool, line []byte) error {
+	if l.writeTimeout <= 0 {
+		return safeWrite(w, line)
+	}
+
+	if stalled.Load() {
+		return ErrOutputStalled
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- safeWrite(w, line)
+	}()
+
+	timer := time.NewTimer(l.writeTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		stalled.Store(true)
+		go func() {
+			<-done
+			stalled.Store(false)
+		}()
+		return ErrWriteTimeout
+	}
+}
+
+// safeWrite writes p to w, turning a panic of the writer into an error.
+func safeWrite(w io.Writer, p []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrOutputPanicked, r)
+		}
+	}()
+
+	_, err = w.Write(p)
+	return err
+}
+
+// FailedWrites returns the number of entries the output failed to write.
+func (l *Logger) FailedWrites() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.failedWrites
+}
+
+// LostEntries returns the number of entries neither the output nor the fallback could write.
+func (l *Logger) LostEntries() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.lostEntries
+}
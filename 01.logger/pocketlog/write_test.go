@@ -0,0 +1,140 @@
+package pocketlog_test
+
+import (
+	"errors"
+	"pocketlog/pocketlog"
+	"testing"
+	"time"
+)
+
+func TestLogger_WriteFailures(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+
+	type testCase struct {
+		output   writerFunc
+		expected error
+	}
+
+	tt := map[string]testCase{
+		"output returns an error": {
+			output: func(p []byte) (int, error) {
+				return 0, errDiskFull
+			},
+			expected: errDiskFull,
+		},
+		"output panics": {
+			output: func(p []byte) (int, error) {
+				panic("closed")
+			},
+			expected: pocketlog.ErrOutputPanicked,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			fallback := &testWriter{}
+			var reported []error
+
+			testLogger := pocketlog.New(pocketlog.LevelInfo,
+				pocketlog.WithOutput(tc.output),
+				pocketlog.WithFallback(fallback),
+				pocketlog.WithErrorHandler(func(err error) { reported = append(reported, err) }),
+			)
+			testLogger.Infof(infoMessage)
+			testLogger.Errorf(errorMessage)
+
+			expected := "[INFO] " + infoMessage + "\n" + "[ERROR] " + errorMessage + "\n"
+			if fallback.contents != expected {
+				t.Errorf("invalid fallback contents, expected %q, got %q", expected, fallback.contents)
+			}
+
+			if len(reported) != 2 || !errors.Is(reported[0], tc.expected) {
+				t.Errorf("expected two %v errors, got %v", tc.expected, reported)
+			}
+
+			if testLogger.FailedWrites() != 2 || testLogger.LostEntries() != 0 {
+				t.Errorf("expected 2 failed writes and no lost entry, got %d and %d", testLogger.FailedWrites(), testLogger.LostEntries())
+			}
+		})
+	}
+}
+
+func TestLogger_WriteFailuresWithoutFallback(t *testing.T) {
+	testLogger := pocketlog.New(pocketlog.LevelInfo, pocketlog.WithOutput(nil), pocketlog.WithFallback(nil))
+	// a nil output panics, which must not reach the caller.
+	testLogger.Infof(infoMessage)
+
+	if testLogger.FailedWrites() != 1 || testLogger.LostEntries() != 1 {
+		t.Errorf("expected 1 failed write and 1 lost entry, got %d and %d", testLogger.FailedWrites(), testLogger.LostEntries())
+	}
+}
+
+func TestLogger_WriteTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	blocking := writerFunc(func(p []byte) (int, error) {
+		<-unblock
+		return len(p), nil
+	})
+
+	fallback := &testWriter{}
+	var reported []error
+
+	testLogger := pocketlog.New(pocketlog.LevelInfo,
+		pocketlog.WithOutput(blocking),
+		pocketlog.WithFallback(fallback),
+		pocketlog.WithWriteTimeout(10*time.Millisecond),
+		pocketlog.WithErrorHandler(func(err error) { reported = append(reported, err) }),
+	)
+
+	testLogger.Infof(infoMessage)
+	testLogger.Errorf(errorMessage)
+	close(unblock)
+
+	expected := "[INFO] " + infoMessage + "\n" + "[ERROR] " + errorMessage + "\n"
+	if fallback.contents != expected {
+		t.Errorf("invalid fallback contents, expected %q, got %q", expected, fallback.contents)
+	}
+
+	if len(reported) != 2 || !errors.Is(reported[0], pocketlog.ErrWriteTimeout) || !errors.Is(reported[1], pocketlog.ErrOutputStalled) {
+		t.Errorf("expected a timeout then a stalled output, got %v", reported)
+	}
+}
+
+// writerFunc turns a function into an io.Writer.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestLogger_WriteTimeoutWithStalledFallback(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	blocking := writerFunc(func(p []byte) (int, error) {
+		<-unblock
+		return len(p), nil
+	})
+
+	testLogger := pocketlog.New(pocketlog.LevelInfo,
+		pocketlog.WithOutput(blocking),
+		pocketlog.WithFallback(blocking),
+		pocketlog.WithWriteTimeout(10*time.Millisecond),
+	)
+
+	logged := make(chan struct{})
+	go func() {
+		testLogger.Infof(infoMessage)
+		testLogger.Errorf(errorMessage)
+		close(logged)
+	}()
+
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the stalled fallback not to block the caller")
+	}
+
+	if testLogger.FailedWrites() != 2 || testLogger.LostEntries() != 2 {
+		t.Errorf("expected 2 failed writes and 2 lost entries, got %d and %d", testLogger.FailedWrites(), testLogger.LostEntries())
+	}
+}
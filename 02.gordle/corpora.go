@@ -0,0 +1,46 @@
+package main
+
+import (
+	"gordle/corpus"
+	"gordle/gordle"
+)
+
+// Names of the bundled English corpus and dictionary.
+const (
+	englishCorpus     = "english.txt"
+	englishDictionary = "english_guesses.txt"
+)
+
+// readCorpus reads the corpus file at the path, or the bundled corpus with the given name if the path is empty.
+func readCorpus(path, bundled string) (gordle.Corpus, error) {
+	if path != "" {
+		return gordle.ReadCorpusFile(path)
+	}
+
+	return gordle.LoadCorpus(corpus.FS, bundled)
+}
+
+// readDictionary reads the dictionary file at the path, or the bundled dictionary with the given name if the path is empty.
+func readDictionary(path, bundled string, l gordle.Language) (gordle.Dictionary, error) {
+	if path != "" {
+		return gordle.ReadDictionary(path, l)
+	}
+
+	return gordle.LoadDictionary(corpus.FS, bundled, l)
+}
+
+// dictionaryOptions returns the option restricting the guesses to the dictionary at the path, or to the bundled one.
+// The bundled dictionary only applies to the bundled corpus, whose words have the same length;
+// a corpus chosen by the player gets no dictionary unless one is given. A path of "none" allows any word.
+func dictionaryOptions(path, bundled string, bundledCorpus bool, l gordle.Language) ([]gordle.Option, error) {
+	if path == "none" || path == "" && (bundled == "" || !bundledCorpus) {
+		return nil, nil
+	}
+
+	dictionary, err := readDictionary(path, bundled, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return []gordle.Option{gordle.WithDictionary(dictionary)}, nil
+}
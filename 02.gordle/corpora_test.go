@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"gordle/gordle"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDictionaryOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "six.txt")
+	err := os.WriteFile(path, []byte("# length: 6\nPLANET\nGARDEN\nSTREAM\n"), 0o600)
+	if err != nil {
+		t.Fatalf("unable to write corpus: %s", err)
+	}
+
+	file, err := readCorpus(path, "")
+	if err != nil {
+		t.Fatalf("unable to read corpus: %s", err)
+	}
+
+	tt := map[string]struct {
+		dictionaryPath string
+		bundledCorpus  bool
+		corpus         []string
+		guess          string
+		expected       error
+	}{
+		"custom corpus of 6 letters": {
+			corpus: file.Solutions(),
+			guess:  "GARDEN",
+		},
+		"bundled corpus": {
+			bundledCorpus: true,
+			corpus:        []string{"HELLO"},
+			guess:         "ZZZZZ",
+			expected:      gordle.ErrNotInDictionary,
+		},
+		"bundled corpus without dictionary": {
+			dictionaryPath: "none",
+			bundledCorpus:  true,
+			corpus:         []string{"HELLO"},
+			guess:          "ZZZZZ",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			opts, err := dictionaryOptions(tc.dictionaryPath, englishDictionary, tc.bundledCorpus, gordle.English)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			g, err := gordle.New(nil, tc.corpus, maxAttempts, append(opts, gordle.WithSeed(1))...)
+			if err != nil {
+				t.Fatalf("unable to start game: %s", err)
+			}
+
+			_, _, err = g.Guess(tc.guess)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// Package corpus bundles the word lists of Gordle, so that the binary works from any directory.
+package corpus
+
+import "embed"
+
+// FS holds the bundled corpora and dictionaries, at the root of the file system.
+// The empty corpus is a test fixture, and isn't bundled.
+//
+//go:embed english.txt english_guesses.txt french.txt spanish.txt
+var FS embed.FS
@@ -0,0 +1,31 @@
+package corpus_test
+
+import (
+	"gordle/corpus"
+	"gordle/gordle"
+	"io/fs"
+	"testing"
+)
+
+func TestFS(t *testing.T) {
+	names, err := fs.Glob(corpus.FS, "*.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(names) != 4 {
+		t.Errorf("expected 4 bundled files, got %v", names)
+	}
+
+	for _, name := range names {
+		c, err := gordle.LoadCorpus(corpus.FS, name)
+		if err != nil {
+			t.Errorf("unable to load %s: %s", name, err)
+			continue
+		}
+
+		if len(c.Solutions()) == 0 {
+			t.Errorf("expected words in %s", name)
+		}
+	}
+}
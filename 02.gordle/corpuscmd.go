@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/gordle"
+	"os"
+)
+
+// checkCorpus reports the problems of a corpus, and optionally writes a cleaned copy of it.
+func checkCorpus(args []string) error {
+	flags := flag.NewFlagSet("corpus", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: gordle corpus [flags] path\n\n"+
+			"Report duplicates, words of the wrong length, non-letters, case variations and blocked words. Flags:\n")
+		flags.PrintDefaults()
+	}
+	lang := flags.String("lang", "en", "Language of the words: en, fr, es, de or tr (defaults to the language in the header of the corpus)")
+	length := flags.Int("length", 0, "Number of characters of every word (0 expects the length in the header of the corpus, or the most common one)")
+	denylistPath := flags.String("denylist", "", "Path to a list of words that must not be in the corpus")
+	output := flags.String("o", "", "Write the cleaned corpus to this file")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	file, err := readCorpusLenient(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("unable to read corpus: %w", err)
+	}
+	corpus := file.Guesses()
+
+	// the header of the corpus tells its language and word length, unless the flags override them
+	if file.Language != "" && !isFlagSet(flags, "lang") {
+		*lang = file.Language
+	}
+	if *length == 0 {
+		*length = file.Length
+	}
+
+	language, err := gordle.LanguageByCode(*lang)
+	if err != nil {
+		return err
+	}
+
+	rules := gordle.CorpusRules{Length: *length, Language: language}
+	if *denylistPath != "" {
+		rules.Denylist, err = gordle.ReadCorpus(*denylistPath)
+		if err != nil {
+			return fmt.Errorf("unable to read denylist: %w", err)
+		}
+	}
+
+	problems := rules.Check(corpus)
+	for _, p := range problems {
+		// the words were listed in the order of the file
+		line := file.Words[p.Index-1].Line
+		fmt.Printf("%s:%d: %q: %s, %s\n", flags.Arg(0), line, p.Word, p.Kind, p.Detail)
+	}
+	fmt.Printf("%d problem(s) in %d words.\n", len(problems), len(corpus))
+
+	if *output == "" {
+		if len(problems) > 0 {
+			return fmt.Errorf("corpus %s has %d problem(s), use -o to write a cleaned copy", flags.Arg(0), len(problems))
+		}
+		return nil
+	}
+
+	// the cleaned corpus keeps the header and the tags of the words without problems.
+	cleaned := gordle.Corpus{Language: file.Language, Length: *length}
+	if isFlagSet(flags, "lang") {
+		cleaned.Language = language.Code
+	}
+
+	dropped := make(map[int]bool, len(problems))
+	for _, p := range problems {
+		dropped[p.Index-1] = true
+	}
+	for i, w := range file.Words {
+		if !dropped[i] {
+			cleaned.Words = append(cleaned.Words, w)
+		}
+	}
+
+	err = writeCorpus(*output, cleaned)
+	if err != nil {
+		return fmt.Errorf("unable to write cleaned corpus: %w", err)
+	}
+
+	fmt.Printf("Wrote %d words to %s.\n", len(cleaned.Words), *output)
+	return nil
+}
+
+// writeCorpus writes the corpus file at the path.
+func writeCorpus(path string, c gordle.Corpus) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.WriteTo(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// readCorpusLenient reads the corpus file at the path, keeping the words of the wrong length for the rules to report.
+func readCorpusLenient(path string) (gordle.Corpus, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return gordle.Corpus{}, err
+	}
+	defer f.Close()
+
+	return gordle.ParseCorpusLenient(f)
+}
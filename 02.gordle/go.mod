@@ -2,4 +2,10 @@ module gordle
 
 go 1.19
 
-require golang.org/x/exp v0.0.0-20221126150942-6ab00d035af9
+require (
+	golang.org/x/exp v0.0.0-20221126150942-6ab00d035af9
+	golang.org/x/term v0.3.0
+	golang.org/x/text v0.5.0
+)
+
+require golang.org/x/sys v0.3.0 // indirect
@@ -0,0 +1,136 @@
+package gordle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Play runs the game in a terminal, reading guesses from the reader of the game.
+// It returns once the game is over, or when the reader fails.
+func (g *Game) Play() error {
+	_, _ = fmt.Fprintln(g.output, "Welcome to Gordle!")
+
+	// a resumed game shows where the player stopped.
+	for _, a := range g.history {
+		_, _ = fmt.Fprintf(g.output, "%s %s\n", a.feedback, string(a.guess))
+	}
+	if len(g.history) > 0 && g.Status() == StatusPlaying {
+		_, _ = fmt.Fprintln(g.output, g.letterSummary())
+	}
+
+	for g.Status() == StatusPlaying {
+		// ask the user for a valid word
+		guess, err := g.ask()
+		if errors.Is(err, ErrTimeUp) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Gordle failed to read your guess: %w", err)
+		}
+
+		fb := g.submit(guess)
+
+		_, _ = fmt.Fprintln(g.output, fb.String())
+
+		if g.Status() == StatusPlaying {
+			_, _ = fmt.Fprintln(g.output, g.letterSummary())
+		}
+	}
+
+	g.logEnd()
+
+	if g.Status() == StatusWon {
+		_, _ = fmt.Fprintf(g.output, "🎉 You won! You found it in %d guess(es)! The word was %s.\n", len(g.history), string(g.solution))
+		return nil
+	}
+
+	if g.TimedOut() {
+		_, _ = fmt.Fprintf(g.output, "⏰ Time's up! The solution was: %s.\n", string(g.solution))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(g.output, "😞 You've lost! The solution was: %s. \n", string(g.solution))
+	return nil
+}
+
+// ask reads input until a valid suggestion is made (and returned).
+// With a time limit, ErrTimeUp is returned as soon as the time is up, or if the line arrives too late.
+func (g *Game) ask() ([]rune, error) {
+	if remaining, ok := g.Remaining(); ok {
+		_, _ = fmt.Fprintf(g.output, "Enter a %d-character guess (%s left):\n", len(g.solution), remaining.Round(time.Second))
+	} else {
+		_, _ = fmt.Fprintf(g.output, "Enter a %d-character guess:\n", len(g.solution))
+	}
+
+	for {
+		timeUp, stop := g.Timer()
+		playerInput, err := g.reader.readLine(timeUp)
+		stop()
+		if err != nil {
+			return nil, err
+		}
+
+		// the clock of the game may disagree with the timer
+		if g.timeIsUp() {
+			return nil, ErrTimeUp
+		}
+
+		guess := g.language.Normalize(string(playerInput))
+
+		err = g.validateGuess(guess)
+		switch {
+		case errors.Is(err, ErrInvalidWordLength):
+			_, _ = fmt.Fprintf(g.errOutput, "Your attempt is invalid with Gordle's solution! Expected %d characters, got %d.\n", len(g.solution), len(guess))
+		case errors.Is(err, ErrNotInDictionary):
+			_, _ = fmt.Fprintf(g.errOutput, "%q is not in the list of allowed words, try another one.\n", string(guess))
+		case errors.Is(err, ErrHardModeViolation):
+			_, _ = fmt.Fprintf(g.errOutput, "%s.\n", err)
+		default:
+			return guess, nil
+		}
+	}
+}
+
+// lineReader reads the lines typed by the player in a goroutine, so that waiting for them can time out.
+// A line still being read when the wait times out is returned by the next call.
+type lineReader struct {
+	reader *bufio.Reader
+	lines  chan lineRead
+	// pending is set while a goroutine is reading a line.
+	pending bool
+}
+
+// lineRead is the result of reading a line.
+type lineRead struct {
+	line []byte
+	err  error
+}
+
+// newLineReader returns a lineReader reading from r.
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{reader: bufio.NewReader(r), lines: make(chan lineRead, 1)}
+}
+
+// readLine returns the next line, without its end of line, or ErrTimeUp if timeUp receives first.
+// A nil timeUp waits for the line.
+func (lr *lineReader) readLine(timeUp <-chan time.Time) ([]byte, error) {
+	if !lr.pending {
+		lr.pending = true
+		go func() {
+			line, _, err := lr.reader.ReadLine()
+			// the line returned by ReadLine is only valid until the next read
+			lr.lines <- lineRead{line: append([]byte(nil), line...), err: err}
+		}()
+	}
+
+	select {
+	case read := <-lr.lines:
+		lr.pending = false
+		return read.line, read.err
+	case <-timeUp:
+		return nil, ErrTimeUp
+	}
+}
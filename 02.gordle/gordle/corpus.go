@@ -1,37 +1,93 @@
 package gordle
 
 import (
+	"bytes"
 	"fmt"
+	"io/fs"
 	"math/rand"
 	"os"
-	"strings"
-	"time"
 )
 
 const ErrCorpusIsEmpty = corpusError("corpus is empty")
 
-// ReadCorpus reads the file located at the given path and returns a list of words
+// ErrNoWordOfLength is returned when no word of the corpus has the requested length.
+const ErrNoWordOfLength = corpusError("no word of the corpus has the requested length")
+
+// ReadCorpus reads the corpus file located at the given path and returns the words that may be picked as the solution.
+// See Corpus for the format of the file.
 func ReadCorpus(path string) ([]string, error) {
+	c, err := ReadCorpusFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	words := c.Solutions()
+	if len(words) == 0 {
+		return nil, ErrCorpusIsEmpty
+	}
+
+	return words, nil
+}
+
+// ReadCorpusFile reads the corpus file located at the given path, with its header and tags.
+func ReadCorpusFile(path string) (Corpus, error) {
 	data, err := os.ReadFile(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to open %q for reading: %w", path, err)
+		return Corpus{}, fmt.Errorf("unable to open %q for reading: %w", path, err)
+	}
+
+	return parseCorpusData(path, data)
+}
+
+// LoadCorpus reads the corpus file with the given name in the file system, such as the corpora embedded in a binary.
+func LoadCorpus(fsys fs.FS, name string) (Corpus, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return Corpus{}, fmt.Errorf("unable to open %q for reading: %w", name, err)
 	}
 
+	return parseCorpusData(name, data)
+}
+
+// parseCorpusData parses the content of the named corpus file.
+func parseCorpusData(name string, data []byte) (Corpus, error) {
 	if len(data) == 0 {
-		return nil, ErrCorpusIsEmpty
+		return Corpus{}, ErrCorpusIsEmpty
 	}
 
-	// we expect the corpus to be a line - or space separated list of words
-	words := strings.Fields(string(data))
+	c, err := ParseCorpus(bytes.NewReader(data))
+	if err != nil {
+		return Corpus{}, fmt.Errorf("unable to parse %q: %w", name, err)
+	}
 
-	return words, nil
+	if len(c.Words) == 0 {
+		return Corpus{}, ErrCorpusIsEmpty
+	}
+
+	return c, nil
 }
 
-func pickWord(corpus []string) string {
-	rand.Seed(time.Now().UTC().UnixNano())
+// FilterByLength returns the words of the corpus that have the given number of characters,
+// once normalised with the language, as they are played.
+func FilterByLength(corpus []string, length int, l Language) ([]string, error) {
+	var words []string
+	for _, word := range corpus {
+		if len(l.Normalize(word)) == length {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%d characters: %w", length, ErrNoWordOfLength)
+	}
+
+	return words, nil
+}
 
-	index := rand.Intn(len(corpus))
+// pickWord returns a random word of the corpus.
+func pickWord(corpus []string, rng *rand.Rand) string {
+	index := rng.Intn(len(corpus))
 
 	return corpus[index]
 }
@@ -1,10 +1,14 @@
 package gordle
 
-import "testing"
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
 
 func TestPickWord(t *testing.T) {
 	corpus := []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}
-	word := pickWord(corpus)
+	word := pickWord(corpus, rand.New(rand.NewSource(time.Now().UnixNano())))
 
 	if !inCorpus(corpus, word) {
 		t.Errorf("expected a word in the corpus, got %q", word)
@@ -20,3 +24,89 @@ func inCorpus(corpus []string, word string) bool {
 
 	return false
 }
+
+func TestPickWord_Seeded(t *testing.T) {
+	corpus := []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ", "HERTZ", "WORLD"}
+
+	for seed := int64(0); seed < 10; seed++ {
+		first := pickWord(corpus, rand.New(rand.NewSource(seed)))
+		second := pickWord(corpus, rand.New(rand.NewSource(seed)))
+
+		if first != second {
+			t.Errorf("seed %d: expected the same word twice, got %q and %q", seed, first, second)
+		}
+	}
+}
+
+func TestPickWordByDifficulty(t *testing.T) {
+	corpus := []string{"COMMON", "RARE", "UNKNOWN"}
+	frequencies := map[string]float64{"COMMON": 1000000, "RARE": 0.01}
+	frequency := func(word string) float64 { return frequencies[word] }
+
+	testCases := map[string]struct {
+		difficulty Difficulty
+		expected   map[string]bool
+	}{
+		"easy only picks words of known frequency": {
+			difficulty: DifficultyEasy,
+			expected:   map[string]bool{"COMMON": true},
+		},
+		"hard favours rare words": {
+			difficulty: DifficultyHard,
+			expected:   map[string]bool{"RARE": true, "UNKNOWN": true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(0); seed < 100; seed++ {
+				word := pickWordByDifficulty(corpus, rand.New(rand.NewSource(seed)), tc.difficulty, frequency, noTag)
+				if !tc.expected[word] {
+					t.Errorf("seed %d: unexpected word %q", seed, word)
+				}
+			}
+		})
+	}
+}
+
+func TestPickWordByDifficulty_Normal(t *testing.T) {
+	corpus := []string{"HELLO", "SALUT", "ΧΑΙΡΕ", "HERTZ", "WORLD"}
+
+	// normal games pick the same words as games without a difficulty, so that seeds keep their solution
+	for seed := int64(0); seed < 10; seed++ {
+		expected := pickWord(corpus, rand.New(rand.NewSource(seed)))
+		got := pickWordByDifficulty(corpus, rand.New(rand.NewSource(seed)), DifficultyNormal, func(string) float64 { return 1 }, noTag)
+		if got != expected {
+			t.Errorf("seed %d: expected %q, got %q", seed, expected, got)
+		}
+	}
+}
+
+// noTag is the difficulty of the words of a corpus without difficulty tags.
+func noTag(string) Difficulty {
+	return DifficultyUnknown
+}
+
+func TestPickWordByDifficulty_Tags(t *testing.T) {
+	corpus := []string{"EASY", "NORMAL", "HARD", "UNTAGGED"}
+	tags := map[string]Difficulty{"EASY": DifficultyEasy, "NORMAL": DifficultyNormal, "HARD": DifficultyHard}
+	noFrequency := func(string) float64 { return 0 }
+
+	for _, difficulty := range []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard} {
+		t.Run(difficulty.String(), func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				word := pickWordByDifficulty(corpus, rand.New(rand.NewSource(seed)), difficulty, noFrequency,
+					func(word string) Difficulty { return tags[word] })
+				if tags[word] != difficulty {
+					t.Errorf("seed %d: expected a word tagged %s, got %q", seed, difficulty, word)
+				}
+			}
+		})
+	}
+
+	// without a word of the difficulty, any word may be picked
+	word := pickWordByDifficulty([]string{"UNTAGGED"}, rand.New(rand.NewSource(1)), DifficultyHard, noFrequency, noTag)
+	if word != "UNTAGGED" {
+		t.Errorf("expected the only word of the corpus, got %q", word)
+	}
+}
@@ -1,8 +1,12 @@
 package gordle_test
 
 import (
+	"errors"
 	"gordle/gordle"
+	"io/fs"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestReadCorpus(t *testing.T) {
@@ -16,6 +20,11 @@ func TestReadCorpus(t *testing.T) {
 			length: 35,
 			err:    nil,
 		},
+		"French corpus with a header": {
+			file:   "../corpus/french.txt",
+			length: 34,
+			err:    nil,
+		},
 		"empty corpus": {
 			file:   "../corpus/empty.txt",
 			length: 0,
@@ -36,3 +45,328 @@ func TestReadCorpus(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterByLength(t *testing.T) {
+	corpus := []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ", "こんにちは", "HI"}
+
+	testCases := map[string]struct {
+		corpus   []string
+		length   int
+		language gordle.Language
+		expected []string
+		err      error
+	}{
+		"5 characters in any script": {
+			corpus:   corpus,
+			length:   5,
+			expected: []string{"HELLO", "SALUT", "ΧΑΙΡΕ", "こんにちは"},
+		},
+		"6 characters": {
+			corpus:   corpus,
+			length:   6,
+			expected: []string{"ПРИВЕТ"},
+		},
+		"no word of that length": {
+			corpus: corpus,
+			length: 7,
+			err:    gordle.ErrNoWordOfLength,
+		},
+		"decomposed accents": {
+			corpus:   []string{"e\u0301cole", "forêt"},
+			length:   5,
+			language: gordle.French,
+			expected: []string{"e\u0301cole", "forêt"},
+		},
+		"sharp s": {
+			corpus:   []string{"straße", "blume"},
+			length:   7,
+			language: gordle.German,
+			expected: []string{"straße"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.language.Code == "" {
+				tc.language = gordle.DefaultLanguage
+			}
+
+			words, err := gordle.FilterByLength(tc.corpus, tc.length, tc.language)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected err %v, got %v", tc.err, err)
+			}
+
+			if len(words) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, words)
+			}
+			for i := range words {
+				if words[i] != tc.expected[i] {
+					t.Errorf("expected %v, got %v", tc.expected, words)
+				}
+			}
+		})
+	}
+}
+
+func TestCorpusRules_Check(t *testing.T) {
+	// the denylist holds whole words: DARN doesn't block DARNS
+	rules := gordle.CorpusRules{Language: gordle.English, Denylist: []string{"darn"}}
+	corpus := []string{"HELLO", "world", "hello", "HELLO", "H3LLO", "hi", "DARNS", "Darns", "darn!", "CAFÉS", "cafes"}
+
+	expected := []gordle.CorpusProblem{
+		{Index: 3, Word: "hello", Kind: gordle.ProblemCaseVariation, Detail: `the word was already listed as "HELLO"`},
+		{Index: 4, Word: "HELLO", Kind: gordle.ProblemDuplicate, Detail: "the word was already listed"},
+		{Index: 5, Word: "H3LLO", Kind: gordle.ProblemNonLetter, Detail: "only letters are allowed"},
+		{Index: 6, Word: "hi", Kind: gordle.ProblemLength, Detail: "expected 5 characters, got 2"},
+		{Index: 8, Word: "Darns", Kind: gordle.ProblemCaseVariation, Detail: `the word was already listed as "DARNS"`},
+		{Index: 9, Word: "darn!", Kind: gordle.ProblemNonLetter, Detail: "only letters are allowed"},
+		{Index: 11, Word: "cafes", Kind: gordle.ProblemCaseVariation, Detail: `the word was already listed as "CAFÉS"`},
+	}
+
+	got := rules.Check(corpus)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), got)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("problem %d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+
+	rules.Denylist = []string{"darns"}
+	got = rules.Check([]string{"HELLO", "darns"})
+	if len(got) != 1 || got[0].Kind != gordle.ProblemBlocked {
+		t.Errorf("expected a blocked word, got %v", got)
+	}
+}
+
+func TestCorpusRules_Clean(t *testing.T) {
+	testCases := map[string]struct {
+		rules    gordle.CorpusRules
+		corpus   []string
+		expected []string
+	}{
+		"most common length": {
+			rules:    gordle.CorpusRules{},
+			corpus:   []string{"hello", "hi", "WORLD", "Hello", "12345", "plant"},
+			expected: []string{"hello", "WORLD", "plant"},
+		},
+		"given length": {
+			rules:    gordle.CorpusRules{Length: 2},
+			corpus:   []string{"hello", "hi", "WORLD", "HI"},
+			expected: []string{"hi"},
+		},
+		"denylist": {
+			rules:    gordle.CorpusRules{Denylist: []string{"WORLD"}},
+			corpus:   []string{"hello", "world"},
+			expected: []string{"hello"},
+		},
+		"French accents": {
+			rules:    gordle.CorpusRules{Language: gordle.French},
+			corpus:   []string{"élève", "ELEVE", "forêt"},
+			expected: []string{"élève", "forêt"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.rules.Clean(tc.corpus)
+			if strings.Join(got, " ") != strings.Join(tc.expected, " ") {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseCorpus(t *testing.T) {
+	file := `# A small corpus
+# language: FR
+# length: 5
+
+ÉCOLE: solution, easy, frequency=61.2
+FORÊT: solution hard # rare in the cities
+ÉTAGE: guess-only
+POMME FLEUR # plain words
+# comments are allowed anywhere
+`
+
+	c, err := gordle.ParseCorpus(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if c.Language != "fr" || c.Length != 5 {
+		t.Errorf("unexpected header: language %q, length %d", c.Language, c.Length)
+	}
+
+	expected := []gordle.CorpusWord{
+		{Word: "ÉCOLE", Line: 5, Solution: true, Difficulty: gordle.DifficultyEasy, Frequency: 61.2},
+		{Word: "FORÊT", Line: 6, Solution: true, Difficulty: gordle.DifficultyHard},
+		{Word: "ÉTAGE", Line: 7, GuessOnly: true},
+		{Word: "POMME", Line: 8},
+		{Word: "FLEUR", Line: 8},
+	}
+	if len(c.Words) != len(expected) {
+		t.Fatalf("expected %d words, got %v", len(expected), c.Words)
+	}
+	for i := range expected {
+		if c.Words[i] != expected[i] {
+			t.Errorf("word %d: expected %+v, got %+v", i, expected[i], c.Words[i])
+		}
+	}
+
+	if got := strings.Join(c.Solutions(), " "); got != "ÉCOLE FORÊT" {
+		t.Errorf("unexpected solutions %q", got)
+	}
+	if got := strings.Join(c.Guesses(), " "); got != "ÉCOLE FORÊT ÉTAGE POMME FLEUR" {
+		t.Errorf("unexpected guesses %q", got)
+	}
+
+	if got := c.Frequencies(); len(got) != 1 || got["ÉCOLE"] != 61.2 {
+		t.Errorf("unexpected frequencies %v", got)
+	}
+}
+
+func TestParseCorpus_LongLine(t *testing.T) {
+	// a list of words on a single line, larger than the default buffer of a bufio.Scanner
+	words := strings.Repeat("HELLO ", 20000)
+
+	c, err := gordle.ParseCorpus(strings.NewReader(words + "\r\nWORLD\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(c.Words) != 20001 || c.Words[20000].Word != "WORLD" || c.Words[20000].Line != 2 {
+		t.Errorf("expected 20001 words, got %d", len(c.Words))
+	}
+}
+
+func TestCorpus_Solutions(t *testing.T) {
+	c, err := gordle.ParseCorpus(strings.NewReader("HELLO WORLD\nPLANT: guess-only\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// without a solution tag, every word but the guess-only ones may be picked
+	if got := strings.Join(c.Solutions(), " "); got != "HELLO WORLD" {
+		t.Errorf("unexpected solutions %q", got)
+	}
+}
+
+func TestParseCorpus_Errors(t *testing.T) {
+	testCases := map[string]string{
+		"unknown tag":                 "HELLO: rare",
+		"invalid frequency":           "HELLO: frequency=often",
+		"several words before tags":   "HELLO WORLD: easy",
+		"solution and guess-only":     "HELLO: solution, guess-only",
+		"unknown language":            "# language: xx\nHELLO",
+		"invalid length":              "# length: five\nHELLO",
+		"word of the wrong length":    "# length: 5\nHELLO HI",
+		"accented word, wrong length": "# language: fr\n# length: 4\nÉCOLE",
+	}
+
+	for name, file := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := gordle.ParseCorpus(strings.NewReader(file))
+			if !errors.Is(err, gordle.ErrInvalidCorpus) {
+				t.Errorf("expected %v, got %v", gordle.ErrInvalidCorpus, err)
+			}
+		})
+	}
+}
+
+func TestParseCorpusLenient(t *testing.T) {
+	c, err := gordle.ParseCorpusLenient(strings.NewReader("# length: 5\nHELLO HI\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the word of the wrong length is kept, for CorpusRules to report it
+	if c.Length != 5 || strings.Join(c.Guesses(), " ") != "HELLO HI" {
+		t.Errorf("unexpected corpus: length %d, words %v", c.Length, c.Words)
+	}
+
+	problems := gordle.CorpusRules{Length: c.Length, Language: gordle.English}.Check(c.Guesses())
+	if len(problems) != 1 || problems[0].Word != "HI" || problems[0].Kind != gordle.ProblemLength {
+		t.Errorf("expected a length problem for HI, got %v", problems)
+	}
+
+	// the header is still checked
+	_, err = gordle.ParseCorpusLenient(strings.NewReader("# length: five\nHELLO"))
+	if !errors.Is(err, gordle.ErrInvalidCorpus) {
+		t.Errorf("expected %v, got %v", gordle.ErrInvalidCorpus, err)
+	}
+}
+
+func TestCorpus_WriteTo(t *testing.T) {
+	c := gordle.Corpus{
+		Language: "fr",
+		Length:   5,
+		Words: []gordle.CorpusWord{
+			{Word: "ÉCOLE", Solution: true, Difficulty: gordle.DifficultyEasy, Frequency: 61.2},
+			{Word: "ÉTAGE", GuessOnly: true},
+			{Word: "POMME"},
+		},
+	}
+
+	var b strings.Builder
+	_, err := c.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "# language: fr\n# length: 5\nÉCOLE: solution, easy, frequency=61.2\nÉTAGE: guess-only\nPOMME\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+
+	// the corpus reads back the same, on the lines it was written to
+	read, err := gordle.ParseCorpus(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if read.Language != c.Language || read.Length != c.Length || len(read.Words) != len(c.Words) {
+		t.Fatalf("expected %+v, got %+v", c, read)
+	}
+	for i, w := range c.Words {
+		w.Line = i + 3
+		if read.Words[i] != w {
+			t.Errorf("word %d: expected %+v, got %+v", i, w, read.Words[i])
+		}
+	}
+}
+
+func TestLoadCorpus(t *testing.T) {
+	fsys := fstest.MapFS{
+		"words.txt": {Data: []byte("# length: 5\nHELLO WORLD\n")},
+		"empty.txt": {Data: []byte("# nothing yet\n")},
+	}
+
+	c, err := gordle.LoadCorpus(fsys, "words.txt")
+	if err != nil || strings.Join(c.Solutions(), " ") != "HELLO WORLD" {
+		t.Errorf("unexpected corpus %v, error %v", c.Words, err)
+	}
+
+	_, err = gordle.LoadCorpus(fsys, "empty.txt")
+	if !errors.Is(err, gordle.ErrCorpusIsEmpty) {
+		t.Errorf("expected %v, got %v", gordle.ErrCorpusIsEmpty, err)
+	}
+
+	_, err = gordle.LoadCorpus(fsys, "missing.txt")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %v, got %v", fs.ErrNotExist, err)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	d, err := gordle.ParseDifficulty("Hard")
+	if err != nil || d != gordle.DifficultyHard {
+		t.Errorf("expected %s, got %s and error %v", gordle.DifficultyHard, d, err)
+	}
+
+	_, err = gordle.ParseDifficulty("nightmare")
+	if !errors.Is(err, gordle.ErrUnknownDifficulty) {
+		t.Errorf("expected %v, got %v", gordle.ErrUnknownDifficulty, err)
+	}
+}
@@ -0,0 +1,295 @@
+package gordle
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidCorpus is returned when a corpus file can't be parsed.
+const ErrInvalidCorpus = corpusError("invalid corpus")
+
+// Tags of the words of a corpus file.
+const (
+	tagSolution  = "solution"
+	tagGuessOnly = "guess-only"
+	// tagFrequency prefixes the frequency of the word, such as frequency=12.5.
+	tagFrequency = "frequency="
+)
+
+// CorpusWord is a word of a corpus file, and its tags.
+type CorpusWord struct {
+	Word string
+	// Line is the line of the file holding the word, starting at 1.
+	Line int
+	// Solution is set when the word is tagged as a solution.
+	Solution bool
+	// GuessOnly words are allowed as guesses, but never picked as the solution.
+	GuessOnly bool
+	// Difficulty tells how hard the word is to find.
+	Difficulty Difficulty
+	// Frequency is how common the word is, such as its number of occurrences per million words. Zero means unknown.
+	Frequency float64
+}
+
+// Corpus is the content of a corpus file.
+//
+// The simplest corpus is a list of words, separated by spaces or new lines.
+// Everything after a # is a comment. Comments of the form "# key: value" before the first word make the header:
+// "language" is the code of the language of the words, and "length" their number of characters.
+// A word followed by a colon has tags, separated by commas or spaces: "solution", "guess-only",
+// a difficulty, "easy", "normal" or "hard", and a frequency, "frequency=12.5". Such a line holds a single word.
+//
+//	# language: fr
+//	# length: 5
+//	ÉCOLE: solution, easy, frequency=61.2
+//	FORÊT
+//	ÉTAGE: guess-only # too hard to find
+type Corpus struct {
+	// Language is the code of the language of the words, empty if the header doesn't tell.
+	Language string
+	// Length is the number of characters of every word, zero if the header doesn't tell.
+	Length int
+	Words  []CorpusWord
+}
+
+// ParseCorpus reads a corpus file.
+func ParseCorpus(r io.Reader) (Corpus, error) {
+	return parseCorpus(r, true)
+}
+
+// ParseCorpusLenient reads a corpus file like ParseCorpus, but keeps the words that don't have the length
+// announced by the header, so that CorpusRules can report them.
+func ParseCorpusLenient(r io.Reader) (Corpus, error) {
+	return parseCorpus(r, false)
+}
+
+// parseCorpus reads a corpus file. If strict is set, every word must have the length announced by the header.
+func parseCorpus(r io.Reader, strict bool) (Corpus, error) {
+	var c Corpus
+	lang := DefaultLanguage
+
+	// the whole corpus is read at once: a list of words may be a single line of any size.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Corpus{}, fmt.Errorf("unable to read corpus: %w", err)
+	}
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := i + 1
+		text, comment, _ := strings.Cut(strings.TrimSuffix(raw, "\r"), "#")
+
+		if strings.TrimSpace(text) == "" {
+			if len(c.Words) > 0 {
+				continue
+			}
+
+			err := c.parseHeader(comment)
+			if err != nil {
+				return Corpus{}, fmt.Errorf("%w: line %d: %s", ErrInvalidCorpus, line, err)
+			}
+			if c.Language != "" {
+				// the code was validated with the header
+				lang, _ = LanguageByCode(c.Language)
+			}
+			continue
+		}
+
+		words, err := parseCorpusLine(text)
+		if err != nil {
+			return Corpus{}, fmt.Errorf("%w: line %d: %s", ErrInvalidCorpus, line, err)
+		}
+
+		for i, w := range words {
+			words[i].Line = line
+			if length := len(lang.Normalize(w.Word)); strict && c.Length != 0 && length != c.Length {
+				return Corpus{}, fmt.Errorf("%w: line %d: %q has %d characters, expected %d", ErrInvalidCorpus, line, w.Word, length, c.Length)
+			}
+		}
+		c.Words = append(c.Words, words...)
+	}
+
+	return c, nil
+}
+
+// parseHeader reads a header comment. Comments that aren't in the "key: value" form are ignored.
+func (c *Corpus) parseHeader(comment string) error {
+	key, value, ok := strings.Cut(comment, ":")
+	if !ok {
+		return nil
+	}
+
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "language":
+		l, err := LanguageByCode(value)
+		if err != nil {
+			return err
+		}
+		c.Language = l.Code
+	case "length":
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 1 {
+			return fmt.Errorf("invalid length %q", value)
+		}
+		c.Length = length
+	}
+
+	return nil
+}
+
+// parseCorpusLine returns the words of a line: a list of words, or a word and its tags.
+func parseCorpusLine(text string) ([]CorpusWord, error) {
+	text, tags, tagged := strings.Cut(text, ":")
+	if !tagged {
+		var words []CorpusWord
+		for _, word := range strings.Fields(text) {
+			words = append(words, CorpusWord{Word: word})
+		}
+		return words, nil
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("expected a single word before the tags, got %d", len(fields))
+	}
+
+	w := CorpusWord{Word: fields[0]}
+	for _, tag := range strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if value, ok := cutPrefixFold(tag, tagFrequency); ok {
+			frequency, err := strconv.ParseFloat(value, 64)
+			if err != nil || frequency < 0 {
+				return nil, fmt.Errorf("invalid frequency %q", value)
+			}
+			w.Frequency = frequency
+			continue
+		}
+
+		switch strings.ToLower(tag) {
+		case tagSolution:
+			w.Solution = true
+		case tagGuessOnly:
+			w.GuessOnly = true
+		case "easy":
+			w.Difficulty = DifficultyEasy
+		case "normal":
+			w.Difficulty = DifficultyNormal
+		case "hard":
+			w.Difficulty = DifficultyHard
+		default:
+			return nil, fmt.Errorf("unknown tag %q", tag)
+		}
+	}
+
+	if w.Solution && w.GuessOnly {
+		return nil, fmt.Errorf("%q can't be both a solution and guess-only", w.Word)
+	}
+
+	return []CorpusWord{w}, nil
+}
+
+// cutPrefixFold returns s without the prefix, ignoring case, and whether s started with it.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
+
+// WriteTo implements the io.WriterTo interface. It writes the corpus in the format read by ParseCorpus:
+// the header, then a word per line, followed by its tags, if any. Comments aren't kept.
+func (c Corpus) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	if c.Language != "" {
+		_, _ = fmt.Fprintf(&b, "# language: %s\n", c.Language)
+	}
+	if c.Length != 0 {
+		_, _ = fmt.Fprintf(&b, "# length: %d\n", c.Length)
+	}
+
+	for _, word := range c.Words {
+		b.WriteString(word.Word)
+		if tags := word.tags(); len(tags) > 0 {
+			b.WriteString(": " + strings.Join(tags, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// tags returns the tags of the word, as written in a corpus file.
+func (w CorpusWord) tags() []string {
+	var tags []string
+	if w.Solution {
+		tags = append(tags, tagSolution)
+	}
+	if w.GuessOnly {
+		tags = append(tags, tagGuessOnly)
+	}
+	if w.Difficulty != DifficultyUnknown {
+		tags = append(tags, w.Difficulty.String())
+	}
+	if w.Frequency > 0 {
+		tags = append(tags, tagFrequency+strconv.FormatFloat(w.Frequency, 'f', -1, 64))
+	}
+
+	return tags
+}
+
+// Solutions returns the words that may be picked as the solution.
+// If any word is tagged as a solution, only those are; otherwise, every word but the guess-only ones are.
+func (c Corpus) Solutions() []string {
+	tagged := false
+	for _, w := range c.Words {
+		tagged = tagged || w.Solution
+	}
+
+	var words []string
+	for _, w := range c.Words {
+		if (tagged && w.Solution) || (!tagged && !w.GuessOnly) {
+			words = append(words, w.Word)
+		}
+	}
+
+	return words
+}
+
+// Frequencies returns the frequency of the words of the corpus, for those that have one.
+func (c Corpus) Frequencies() map[string]float64 {
+	frequencies := make(map[string]float64)
+	for _, w := range c.Words {
+		if w.Frequency > 0 {
+			frequencies[w.Word] = w.Frequency
+		}
+	}
+
+	return frequencies
+}
+
+// Difficulties returns the difficulty of the words of the corpus, for those tagged with one.
+func (c Corpus) Difficulties() map[string]Difficulty {
+	difficulties := make(map[string]Difficulty)
+	for _, w := range c.Words {
+		if w.Difficulty != DifficultyUnknown {
+			difficulties[w.Word] = w.Difficulty
+		}
+	}
+
+	return difficulties
+}
+
+// Guesses returns every word of the corpus, which are all allowed as guesses.
+func (c Corpus) Guesses() []string {
+	words := make([]string, len(c.Words))
+	for i, w := range c.Words {
+		words[i] = w.Word
+	}
+
+	return words
+}
@@ -0,0 +1,154 @@
+package gordle
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// ProblemKind tells what is wrong with a word of a corpus.
+type ProblemKind byte
+
+const (
+	// ProblemNonLetter means the word contains characters that aren't letters, such as digits or punctuation.
+	ProblemNonLetter ProblemKind = iota
+	// ProblemLength means the word doesn't have the same number of characters as the others.
+	ProblemLength
+	// ProblemBlocked means the word is in the denylist.
+	ProblemBlocked
+	// ProblemDuplicate means the word appears earlier in the corpus.
+	ProblemDuplicate
+	// ProblemCaseVariation means the word appears earlier in the corpus, spelt differently, such as hello and HELLO.
+	ProblemCaseVariation
+)
+
+// String implements the Stringer interface.
+func (k ProblemKind) String() string {
+	switch k {
+	case ProblemNonLetter:
+		return "non-letter"
+	case ProblemLength:
+		return "length"
+	case ProblemBlocked:
+		return "blocked"
+	case ProblemDuplicate:
+		return "duplicate"
+	case ProblemCaseVariation:
+		return "case variation"
+	default:
+		// This should never happen.
+		return "unknown"
+	}
+}
+
+// CorpusProblem describes a word that doesn't belong in a clean corpus.
+type CorpusProblem struct {
+	// Index is the position of the word in the list given to Check, starting at 1.
+	// It is not a line number: a line of a corpus file may hold several words, or none.
+	Index  int
+	Word   string
+	Kind   ProblemKind
+	Detail string
+}
+
+// String implements the Stringer interface.
+func (p CorpusProblem) String() string {
+	return fmt.Sprintf("%d: %q: %s, %s", p.Index, p.Word, p.Kind, p.Detail)
+}
+
+// CorpusRules describe what a clean corpus contains.
+type CorpusRules struct {
+	// Length is the number of characters of every word. Zero expects the most common length of the corpus.
+	Length int
+	// Language normalises the words, to compare them.
+	Language Language
+	// Denylist holds the words that must not be in the corpus, such as profanities.
+	Denylist []string
+}
+
+// Check returns the problems of the corpus, in the order of the words. Each word reports its first problem only.
+func (r CorpusRules) Check(corpus []string) []CorpusProblem {
+	problems, _ := r.check(corpus)
+	return problems
+}
+
+// Clean returns the words of the corpus that have no problem, spelt as in the corpus.
+// The first occurrence of a duplicated word is kept.
+func (r CorpusRules) Clean(corpus []string) []string {
+	_, words := r.check(corpus)
+	return words
+}
+
+// check returns the problems of the corpus, and its clean words.
+func (r CorpusRules) check(corpus []string) ([]CorpusProblem, []string) {
+	length := r.Length
+	if length == 0 {
+		length = r.commonLength(corpus)
+	}
+
+	blocked := make(map[string]struct{}, len(r.Denylist))
+	for _, word := range r.Denylist {
+		blocked[string(r.Language.Normalize(word))] = struct{}{}
+	}
+
+	// seen holds the words kept so far, as they were written in the corpus, keyed by their normalised form.
+	seen := make(map[string]string, len(corpus))
+
+	var problems []CorpusProblem
+	var words []string
+	for i, word := range corpus {
+		normalised := r.Language.Normalize(word)
+		problem := CorpusProblem{Index: i + 1, Word: word}
+
+		first, isSeen := seen[string(normalised)]
+		_, isBlocked := blocked[string(normalised)]
+		switch {
+		case !isLetters(normalised):
+			problem.Kind, problem.Detail = ProblemNonLetter, "only letters are allowed"
+		case len(normalised) != length:
+			problem.Kind, problem.Detail = ProblemLength, fmt.Sprintf("expected %d characters, got %d", length, len(normalised))
+		case isBlocked:
+			problem.Kind, problem.Detail = ProblemBlocked, "the word is in the denylist"
+		case isSeen && first == word:
+			problem.Kind, problem.Detail = ProblemDuplicate, "the word was already listed"
+		case isSeen:
+			problem.Kind, problem.Detail = ProblemCaseVariation, fmt.Sprintf("the word was already listed as %q", first)
+		default:
+			seen[string(normalised)] = word
+			// the original spelling keeps the accents the language folds, such as in French.
+			words = append(words, word)
+			continue
+		}
+
+		problems = append(problems, problem)
+	}
+
+	return problems, words
+}
+
+// commonLength returns the most frequent number of characters of the words, the smallest one in case of a tie.
+func (r CorpusRules) commonLength(corpus []string) int {
+	counts := make(map[int]int)
+	for _, word := range corpus {
+		counts[len(r.Language.Normalize(word))]++
+	}
+
+	common := 0
+	for length, count := range counts {
+		if count > counts[common] || (count == counts[common] && length < common) {
+			common = length
+		}
+	}
+
+	return common
+}
+
+// isLetters tells whether every character of the word is a letter, or a mark combined with one.
+func isLetters(word []rune) bool {
+	for _, r := range word {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+			return false
+		}
+	}
+
+	return true
+}
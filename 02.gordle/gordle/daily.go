@@ -0,0 +1,30 @@
+package gordle
+
+import (
+	"math/rand"
+	"time"
+)
+
+// dailyEpoch is the day of the first daily word, Gordle #0.
+var dailyEpoch = time.Date(2022, time.December, 3, 0, 0, 0, 0, time.UTC)
+
+// dailySeed shuffles the corpus, so that consecutive days don't follow the order of the corpus.
+const dailySeed = 20221203
+
+// DailyNumber returns the number of the daily word of the date, counted in days since the first one.
+func DailyNumber(date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(dailyEpoch).Hours() / 24)
+}
+
+// dailyWord returns the word of the day. Every word of the corpus is used once before any repeats.
+func dailyWord(corpus []string, date time.Time) string {
+	order := rand.New(rand.NewSource(dailySeed)).Perm(len(corpus))
+
+	n := DailyNumber(date) % len(corpus)
+	if n < 0 {
+		n += len(corpus)
+	}
+
+	return corpus[order[n]]
+}
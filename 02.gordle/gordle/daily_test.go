@@ -0,0 +1,86 @@
+package gordle_test
+
+import (
+	"gordle/gordle"
+	"testing"
+	"time"
+)
+
+func TestDailyNumber(t *testing.T) {
+	testCases := map[string]struct {
+		date     time.Time
+		expected int
+	}{
+		"first day": {
+			date:     time.Date(2022, time.December, 3, 0, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+		"late on the first day, elsewhere": {
+			date:     time.Date(2022, time.December, 3, 23, 59, 0, 0, time.FixedZone("UTC+14", 14*3600)),
+			expected: 0,
+		},
+		"a year later": {
+			date:     time.Date(2023, time.December, 3, 12, 0, 0, 0, time.UTC),
+			expected: 365,
+		},
+		"before the first day": {
+			date:     time.Date(2022, time.December, 1, 12, 0, 0, 0, time.UTC),
+			expected: -2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := gordle.DailyNumber(tc.date); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithDailyWord(t *testing.T) {
+	corpus, err := gordle.ReadCorpus("../corpus/english.txt")
+	if err != nil {
+		t.Fatalf("unable to read corpus: %s", err)
+	}
+
+	solution := func(date time.Time) string {
+		g, _ := gordle.New(nil, corpus, 1, gordle.WithDailyWord(date))
+		// lose the game to reveal the solution
+		_, _, _ = g.Guess("#####")
+		return g.Solution()
+	}
+
+	morning := solution(time.Date(2023, time.March, 14, 8, 0, 0, 0, time.UTC))
+	evening := solution(time.Date(2023, time.March, 14, 20, 0, 0, 0, time.UTC))
+	if morning == "" || morning != evening {
+		t.Errorf("expected the same word all day, got %q and %q", morning, evening)
+	}
+
+	// every word is used once over as many days as there are words in the corpus
+	seen := make(map[string]bool)
+	for day := 0; day < len(corpus); day++ {
+		seen[solution(time.Date(2023, time.March, 14+day, 12, 0, 0, 0, time.UTC))] = true
+	}
+	if len(seen) != len(corpus) {
+		t.Errorf("expected %d different words, got %d", len(corpus), len(seen))
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	corpus, err := gordle.ReadCorpus("../corpus/english.txt")
+	if err != nil {
+		t.Fatalf("unable to read corpus: %s", err)
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		first, _ := gordle.New(nil, corpus, 1, gordle.WithSeed(seed))
+		second, _ := gordle.New(nil, corpus, 1, gordle.WithSeed(seed))
+		_, _, _ = first.Guess("#####")
+		_, _, _ = second.Guess("#####")
+
+		if first.Solution() != second.Solution() {
+			t.Errorf("seed %d: expected the same solution, got %q and %q", seed, first.Solution(), second.Solution())
+		}
+	}
+}
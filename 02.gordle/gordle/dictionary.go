@@ -0,0 +1,55 @@
+package gordle
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// Dictionary holds the words a player is allowed to guess.
+// It is independent of the corpus the solution is picked from, and usually much larger.
+type Dictionary struct {
+	// words is a set of uppercase words, which keeps lookups cheap however large the dictionary.
+	words map[string]struct{}
+}
+
+// NewDictionary returns a dictionary of the given words, normalised with the rules of the language.
+func NewDictionary(words []string, l Language) Dictionary {
+	d := Dictionary{words: make(map[string]struct{}, len(words))}
+	for _, word := range words {
+		d.words[string(l.Normalize(word))] = struct{}{}
+	}
+
+	return d
+}
+
+// ReadDictionary reads the file located at the given path, which has the same format as a corpus.
+// Every word of the file is allowed, including the guess-only ones.
+func ReadDictionary(path string, l Language) (Dictionary, error) {
+	c, err := ReadCorpusFile(path)
+	if err != nil {
+		return Dictionary{}, fmt.Errorf("unable to read dictionary: %w", err)
+	}
+
+	return NewDictionary(c.Guesses(), l), nil
+}
+
+// LoadDictionary reads the file with the given name in the file system, which has the same format as a corpus.
+func LoadDictionary(fsys fs.FS, name string, l Language) (Dictionary, error) {
+	c, err := LoadCorpus(fsys, name)
+	if err != nil {
+		return Dictionary{}, fmt.Errorf("unable to read dictionary: %w", err)
+	}
+
+	return NewDictionary(c.Guesses(), l), nil
+}
+
+// Contains tells whether the normalised word is in the dictionary.
+func (d Dictionary) Contains(word []rune) bool {
+	_, ok := d.words[string(word)]
+	return ok
+}
+
+// Len returns the number of words in the dictionary.
+func (d Dictionary) Len() int {
+	return len(d.words)
+}
@@ -0,0 +1,77 @@
+package gordle_test
+
+import (
+	"errors"
+	"gordle/gordle"
+	"testing"
+)
+
+func TestReadDictionary(t *testing.T) {
+	testCase := map[string]struct {
+		file   string
+		length int
+		err    error
+	}{
+		"English dictionary": {
+			file:   "../corpus/english_guesses.txt",
+			length: 1410,
+			err:    nil,
+		},
+		"empty dictionary": {
+			file:   "../corpus/empty.txt",
+			length: 0,
+			err:    gordle.ErrCorpusIsEmpty,
+		},
+	}
+
+	for name, tc := range testCase {
+		t.Run(name, func(t *testing.T) {
+			dictionary, err := gordle.ReadDictionary(tc.file, gordle.English)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected err %v, got %v", tc.err, err)
+			}
+
+			if tc.length != dictionary.Len() {
+				t.Errorf("expected %d, got %d", tc.length, dictionary.Len())
+			}
+		})
+	}
+}
+
+func TestDictionary_ContainsCorpus(t *testing.T) {
+	dictionary, err := gordle.ReadDictionary("../corpus/english_guesses.txt", gordle.English)
+	if err != nil {
+		t.Fatalf("unable to read dictionary: %s", err)
+	}
+
+	corpus, err := gordle.ReadCorpus("../corpus/english.txt")
+	if err != nil {
+		t.Fatalf("unable to read corpus: %s", err)
+	}
+
+	for _, word := range corpus {
+		if !dictionary.Contains([]rune(word)) {
+			t.Errorf("expected %q to be an allowed guess", word)
+		}
+	}
+}
+
+func BenchmarkDictionary_Contains(b *testing.B) {
+	words := make([]string, 0, 26*26*26*26)
+	for _, a := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		for _, c := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+			for _, d := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+				for _, e := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+					words = append(words, string([]rune{a, c, d, e, 'S'}))
+				}
+			}
+		}
+	}
+	dictionary := gordle.NewDictionary(words, gordle.DefaultLanguage)
+	guess := []rune("ZZZZS")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dictionary.Contains(guess)
+	}
+}
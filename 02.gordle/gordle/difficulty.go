@@ -0,0 +1,110 @@
+package gordle
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ErrUnknownDifficulty is returned when parsing a difficulty that doesn't exist.
+const ErrUnknownDifficulty = corpusError("unknown difficulty")
+
+// Difficulty ranks how hard a word is to find.
+type Difficulty byte
+
+const (
+	// DifficultyUnknown means the corpus doesn't tell how hard the word is.
+	DifficultyUnknown Difficulty = iota
+	// DifficultyEasy words are common ones.
+	DifficultyEasy
+	// DifficultyNormal words are neither common nor rare.
+	DifficultyNormal
+	// DifficultyHard words are rare ones.
+	DifficultyHard
+)
+
+// String implements the Stringer interface.
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyUnknown:
+		return "unknown"
+	case DifficultyEasy:
+		return "easy"
+	case DifficultyNormal:
+		return "normal"
+	case DifficultyHard:
+		return "hard"
+	default:
+		// This should never happen.
+		return "invalid"
+	}
+}
+
+// ParseDifficulty returns the difficulty with the given name: easy, normal or hard.
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, d := range []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard} {
+		if strings.EqualFold(name, d.String()) {
+			return d, nil
+		}
+	}
+
+	return DifficultyUnknown, fmt.Errorf("%q: %w", name, ErrUnknownDifficulty)
+}
+
+// pickWordByDifficulty returns a random word of the corpus, biased by the frequency of the words:
+// easy picks common words more often, and hard picks rare words more often.
+// A corpus without known frequencies only picks the words tagged with the difficulty, if there are any.
+// Otherwise, and for other difficulties, any word is picked with the same probability.
+func pickWordByDifficulty(corpus []string, rng *rand.Rand, difficulty Difficulty, frequency func(word string) float64, tag func(word string) Difficulty) string {
+	if difficulty == DifficultyUnknown {
+		return pickWord(corpus, rng)
+	}
+
+	known := false
+	for _, word := range corpus {
+		known = known || frequency(word) > 0
+	}
+
+	if !known {
+		var tagged []string
+		for _, word := range corpus {
+			if tag(word) == difficulty {
+				tagged = append(tagged, word)
+			}
+		}
+
+		if len(tagged) == 0 {
+			return pickWord(corpus, rng)
+		}
+		return pickWord(tagged, rng)
+	}
+
+	if difficulty != DifficultyEasy && difficulty != DifficultyHard {
+		return pickWord(corpus, rng)
+	}
+
+	weights := make([]float64, len(corpus))
+	total := 0.0
+	for i, word := range corpus {
+		f := frequency(word)
+		switch difficulty {
+		case DifficultyEasy:
+			weights[i] = f
+		case DifficultyHard:
+			// words of unknown frequency are considered the rarest ones
+			weights[i] = 1 / (1 + f)
+		}
+		total += weights[i]
+	}
+
+	target := rng.Float64() * total
+	for i, w := range weights {
+		target -= w
+		if target < 0 {
+			return corpus[i]
+		}
+	}
+
+	// rounding errors may leave a tiny remainder
+	return corpus[len(corpus)-1]
+}
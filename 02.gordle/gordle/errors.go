@@ -1,5 +1,11 @@
 package gordle
 
+// ErrInvalidSave is returned when a saved game can't be restored.
+const ErrInvalidSave = saveError("invalid saved game")
+
+// ErrInvalidEventLog is returned when an event log can't be replayed.
+const ErrInvalidEventLog = saveError("invalid event log")
+
 // corpusError defines a sentinel error.
 type corpusError string
 
@@ -7,3 +13,11 @@ type corpusError string
 func (e corpusError) Error() string {
 	return string(e)
 }
+
+// saveError defines a sentinel error.
+type saveError string
+
+// Error is the implementation of the error interface by saveError
+func (e saveError) Error() string {
+	return string(e)
+}
@@ -0,0 +1,230 @@
+package gordle
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Types of the events of the log.
+const (
+	eventStart  = "start"
+	eventResume = "resume"
+	eventGuess  = "guess"
+	eventEnd    = "end"
+)
+
+// event is a line of the event log written by a game: the start of the game, its resumption from a save,
+// a guess, or the end of the game.
+type event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	// Solution is obfuscated, as in saved games, so that the log doesn't spoil the game it's written during.
+	Solution    string `json:"solution,omitempty"`
+	MaxAttempts int    `json:"maxAttempts,omitempty"`
+	HardMode    bool   `json:"hardMode,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Guess       string `json:"guess,omitempty"`
+	Feedback    []int  `json:"feedback,omitempty"`
+	Status      string `json:"status,omitempty"`
+	TimedOut    bool   `json:"timedOut,omitempty"`
+}
+
+// logStart writes the start event.
+func (g *Game) logStart() {
+	g.writeEvent(event{
+		Type:        eventStart,
+		Time:        g.started,
+		Solution:    obfuscate(string(g.solution)),
+		MaxAttempts: g.maxAttempts,
+		HardMode:    g.hardMode,
+		Language:    g.language.Code,
+	})
+}
+
+// logResume writes the resume event of a restored game. Its guesses were logged when they were made, if at all.
+func (g *Game) logResume() {
+	g.writeEvent(event{
+		Type:        eventResume,
+		Time:        g.started,
+		Solution:    obfuscate(string(g.solution)),
+		MaxAttempts: g.maxAttempts,
+		HardMode:    g.hardMode,
+		Language:    g.language.Code,
+	})
+}
+
+// logGuess writes a guess event.
+func (g *Game) logGuess(a attempt) {
+	hints := make([]int, len(a.feedback))
+	for i, h := range a.feedback {
+		hints[i] = int(h)
+	}
+
+	g.writeEvent(event{Type: eventGuess, Time: a.at, Guess: string(a.guess), Feedback: hints})
+}
+
+// logEnd writes the end event, once.
+func (g *Game) logEnd() {
+	if g.logEnded || g.eventLog == nil {
+		return
+	}
+	g.logEnded = true
+
+	end := event{Type: eventEnd, Time: g.now(), Status: g.Status().String(), TimedOut: g.TimedOut()}
+	if g.TimedOut() {
+		deadline, _ := g.deadline()
+		end.Time = deadline
+	}
+
+	g.writeEvent(end)
+}
+
+// writeEvent appends an event to the log, if any. Errors are reported to the error output, as the game can go on without the log.
+func (g *Game) writeEvent(e event) {
+	if g.eventLog == nil {
+		return
+	}
+
+	err := json.NewEncoder(g.eventLog).Encode(e)
+	if err != nil {
+		_, _ = fmt.Fprintf(g.errOutput, "unable to write event log: %s\n", err)
+	}
+}
+
+// Replay is a game read from an event log.
+type Replay struct {
+	Solution    string
+	MaxAttempts int
+	HardMode    bool
+	Language    string
+	Started     time.Time
+	Attempts    []ReplayAttempt
+	// Status is StatusPlaying if the log stops before the end of the game.
+	Status   Status
+	TimedOut bool
+	Ended    time.Time
+}
+
+// ReplayAttempt is a guess of a replayed game.
+type ReplayAttempt struct {
+	Attempt
+	Time time.Time
+}
+
+// ReadReplays reads the games of an event log written with WithEventLog, in the order they were started.
+// A game restored with Load goes on with the unfinished game of the same solution.
+// If the log doesn't have it, the replay starts when the game was resumed, without the previous guesses.
+// The feedback of every guess is checked against the solution: ErrInvalidEventLog is returned if it doesn't match,
+// for instance because the log was edited, or because the rules of the game changed since it was recorded.
+func ReadReplays(r io.Reader) ([]Replay, error) {
+	var replays []Replay
+	// current is the index of the game the guesses belong to.
+	current := -1
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		var e event
+		err := json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidEventLog, line, err)
+		}
+
+		if e.Type == eventStart || e.Type == eventResume {
+			solution, err := deobfuscate(e.Solution)
+			if err != nil || solution == "" {
+				return nil, fmt.Errorf("%w: line %d: unreadable solution", ErrInvalidEventLog, line)
+			}
+
+			// a resumed game goes on where it stopped, if the log has its start.
+			if e.Type == eventResume {
+				if i, ok := unfinishedReplay(replays, solution); ok {
+					current = i
+					continue
+				}
+			}
+
+			replays = append(replays, Replay{
+				Solution:    solution,
+				MaxAttempts: e.MaxAttempts,
+				HardMode:    e.HardMode,
+				Language:    e.Language,
+				Started:     e.Time,
+			})
+			current = len(replays) - 1
+			continue
+		}
+
+		if current < 0 {
+			return nil, fmt.Errorf("%w: line %d: %s event before the start of a game", ErrInvalidEventLog, line, e.Type)
+		}
+		replay := &replays[current]
+
+		switch e.Type {
+		case eventGuess:
+			a, err := replay.verify(e)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidEventLog, line, err)
+			}
+			replay.Attempts = append(replay.Attempts, a)
+		case eventEnd:
+			status, ok := parseStatus(e.Status)
+			if !ok || status == StatusPlaying {
+				return nil, fmt.Errorf("%w: line %d: invalid status %q", ErrInvalidEventLog, line, e.Status)
+			}
+			replay.Status, replay.TimedOut, replay.Ended = status, e.TimedOut, e.Time
+		default:
+			return nil, fmt.Errorf("%w: line %d: unknown event %q", ErrInvalidEventLog, line, e.Type)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read event log: %w", err)
+	}
+
+	return replays, nil
+}
+
+// unfinishedReplay returns the index of the last game of the solution that didn't end, if any.
+func unfinishedReplay(replays []Replay, solution string) (int, bool) {
+	for i := len(replays) - 1; i >= 0; i-- {
+		if replays[i].Solution == solution && replays[i].Status == StatusPlaying {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// verify checks the recorded feedback of a guess event matches the one computed against the solution.
+func (r *Replay) verify(e event) (ReplayAttempt, error) {
+	guess, solution := []rune(e.Guess), []rune(r.Solution)
+	if len(guess) != len(solution) {
+		return ReplayAttempt{}, fmt.Errorf("%q doesn't have the same number of characters as the solution", e.Guess)
+	}
+
+	fb := make(Feedback, len(e.Feedback))
+	for i, h := range e.Feedback {
+		fb[i] = Hint(h)
+	}
+
+	expected := computeFeedback(guess, solution)
+	if !expected.Equal(fb) {
+		return ReplayAttempt{}, fmt.Errorf("recorded feedback %s of %q doesn't match %s", fb, e.Guess, expected)
+	}
+
+	return ReplayAttempt{Attempt: Attempt{Word: e.Guess, Feedback: fb}, Time: e.Time}, nil
+}
+
+// parseStatus returns the status written by Status.String.
+func parseStatus(s string) (Status, bool) {
+	for _, status := range []Status{StatusPlaying, StatusWon, StatusLost} {
+		if status.String() == s {
+			return status, true
+		}
+	}
+
+	return StatusPlaying, false
+}
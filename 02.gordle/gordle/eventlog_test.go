@@ -0,0 +1,169 @@
+package gordle_test
+
+import (
+	"bytes"
+	"errors"
+	"gordle/gordle"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadReplays(t *testing.T) {
+	clock := newFakeClock()
+	log := &strings.Builder{}
+
+	won, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithClock(clock.Now), gordle.WithEventLog(log))
+	for _, word := range []string{"world", "hello"} {
+		clock.advance(5 * time.Second)
+		if _, _, err := won.Guess(word); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	timedOut, _ := gordle.New(nil, []string{"PLANT"}, 6, gordle.WithClock(clock.Now), gordle.WithEventLog(log), gordle.WithTimeLimit(time.Minute))
+	clock.advance(10 * time.Second)
+	if _, _, err := timedOut.Guess("salut"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	clock.advance(time.Minute)
+	if _, _, err := timedOut.Guess("plant"); !errors.Is(err, gordle.ErrTimeUp) {
+		t.Fatalf("expected %v, got %v", gordle.ErrTimeUp, err)
+	}
+
+	replays, err := gordle.ReadReplays(strings.NewReader(log.String()))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(replays) != 2 {
+		t.Fatalf("expected 2 games, got %d", len(replays))
+	}
+
+	first := replays[0]
+	if first.Solution != "HELLO" || first.Status != gordle.StatusWon || len(first.Attempts) != 2 {
+		t.Errorf("unexpected first game %+v", first)
+	}
+	if got := first.Attempts[0]; got.Word != "WORLD" || got.Feedback.String() != "⬜️🟡⬜️💚⬜️" || got.Time.Sub(first.Started) != 5*time.Second {
+		t.Errorf("unexpected first guess %+v", got)
+	}
+	if first.Ended.Sub(first.Started) != 10*time.Second {
+		t.Errorf("expected the first game to last 10s, got %s", first.Ended.Sub(first.Started))
+	}
+
+	second := replays[1]
+	if second.Solution != "PLANT" || second.Status != gordle.StatusLost || !second.TimedOut || len(second.Attempts) != 1 {
+		t.Errorf("unexpected second game %+v", second)
+	}
+	if second.Ended.Sub(second.Started) != time.Minute {
+		t.Errorf("expected the second game to end at the time limit, got %s", second.Ended.Sub(second.Started))
+	}
+}
+
+func TestReadReplays_Unfinished(t *testing.T) {
+	log := &strings.Builder{}
+
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithEventLog(log))
+	_, _, _ = g.Guess("world")
+
+	replays, err := gordle.ReadReplays(strings.NewReader(log.String()))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(replays) != 1 || replays[0].Status != gordle.StatusPlaying || len(replays[0].Attempts) != 1 {
+		t.Errorf("expected an unfinished game, got %+v", replays)
+	}
+}
+
+func TestReadReplays_Resumed(t *testing.T) {
+	clock := newFakeClock()
+	log := &strings.Builder{}
+
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithClock(clock.Now), gordle.WithEventLog(log))
+	clock.advance(5 * time.Second)
+	_, _, _ = g.Guess("world")
+
+	save := &bytes.Buffer{}
+	_ = g.Save(save)
+
+	// another game is played before the first one is resumed
+	other, _ := gordle.New(nil, []string{"PLANT"}, 6, gordle.WithClock(clock.Now), gordle.WithEventLog(log))
+	_, _, _ = other.Guess("plant")
+
+	clock.advance(time.Hour)
+	resumed, err := gordle.Load(save, nil, gordle.WithClock(clock.Now), gordle.WithEventLog(log))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	clock.advance(5 * time.Second)
+	_, _, _ = resumed.Guess("hello")
+
+	replays, err := gordle.ReadReplays(strings.NewReader(log.String()))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(replays) != 2 {
+		t.Fatalf("expected 2 games, got %+v", replays)
+	}
+
+	first := replays[0]
+	if first.Solution != "HELLO" || first.Status != gordle.StatusWon || len(first.Attempts) != 2 {
+		t.Fatalf("expected the resumed game to go on, got %+v", first)
+	}
+	if got := first.Attempts[0].Time.Sub(first.Started); got != 5*time.Second {
+		t.Errorf("expected the first guess after 5s, got %s", got)
+	}
+	if got := first.Attempts[1].Time.Sub(first.Started); got != time.Hour+10*time.Second {
+		t.Errorf("expected the second guess after the break, got %s", got)
+	}
+
+	// without its start, a resumed game only has the guesses made after the resume
+	rest := log.String()[strings.Index(log.String(), `{"type":"resume"`):]
+	replays, err = gordle.ReadReplays(strings.NewReader(rest))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(replays) != 1 || len(replays[0].Attempts) != 1 || replays[0].Status != gordle.StatusWon {
+		t.Errorf("expected the game from its resume, got %+v", replays)
+	}
+}
+
+func TestReadReplays_Errors(t *testing.T) {
+	log := &strings.Builder{}
+
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithEventLog(log))
+	_, _, _ = g.Guess("world")
+	_, _, _ = g.Guess("hello")
+	recorded := log.String()
+
+	tt := map[string]struct {
+		log string
+	}{
+		"tampered feedback": {
+			log: strings.Replace(recorded, `"feedback":[0,1,0,2,0]`, `"feedback":[2,2,2,2,2]`, 1),
+		},
+		"wrong length": {
+			log: strings.Replace(recorded, `"guess":"WORLD"`, `"guess":"WORLDS"`, 1),
+		},
+		"guess before start": {
+			log: recorded[strings.Index(recorded, "\n")+1:],
+		},
+		"invalid status": {
+			log: strings.Replace(recorded, `"status":"won"`, `"status":"playing"`, 1),
+		},
+		"not json": {
+			log: "hello\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := gordle.ReadReplays(strings.NewReader(tc.log))
+			if !errors.Is(err, gordle.ErrInvalidEventLog) {
+				t.Errorf("expected %v, got %v", gordle.ErrInvalidEventLog, err)
+			}
+		})
+	}
+}
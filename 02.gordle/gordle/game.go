@@ -1,117 +1,326 @@
 package gordle
 
 import (
-	"bufio"
 	"fmt"
 	"golang.org/x/exp/slices"
 	"io"
+	"math/rand"
 	"os"
-	"strings"
+	"time"
 )
 
 // Game holds all the information we need to play a game of Gordle.
+// It can be driven by Guess, or played in a terminal with Play.
 type Game struct {
-	reader      *bufio.Reader
+	reader      *lineReader
 	solution    []rune
 	maxAttempts int
+	// dictionary lists the allowed guesses. Any word is allowed if it is nil.
+	dictionary *Dictionary
+	// hardMode requires every guess to reuse the hints revealed so far.
+	hardMode bool
+	// history holds the previous guesses and their feedback.
+	history []attempt
+	// letters holds the best hint received by every character played so far.
+	letters map[rune]Hint
+	// language normalises the words of the corpus and the guesses.
+	language Language
+	// rng picks the solution. It is seeded with the current time unless an option provides it.
+	rng *rand.Rand
+	// difficulty biases the choice of the solution, with the frequencies of the words of the corpus,
+	// or their difficulty tags if no frequency is known.
+	difficulty   Difficulty
+	frequencies  map[string]float64
+	difficulties map[string]Difficulty
+	// daily is the date the solution is derived from, in daily mode.
+	daily *time.Time
+	// now reads the time. The game starts when it's created, and must end before the time limits, if any.
+	now            func() time.Time
+	started        time.Time
+	gameTimeLimit  time.Duration
+	guessTimeLimit time.Duration
+	// observers are notified of every valid guess.
+	observers []Observer
+	// eventLog receives the events of the game, if set. logEnded is set once the end of the game is written.
+	eventLog io.Writer
+	logEnded bool
+	// output and errOutput are where Play writes its messages.
+	output    io.Writer
+	errOutput io.Writer
+}
+
+// attempt is a guess and the feedback it received.
+type attempt struct {
+	guess    []rune
+	feedback Feedback
+	// at is the time of the guess.
+	at time.Time
+}
+
+// Attempt is a guess and the feedback it received, as exposed to the players.
+type Attempt struct {
+	Word     string
+	Feedback Feedback
+}
+
+// Status tells whether a game is over.
+type Status byte
+
+const (
+	// StatusPlaying means the player can still guess.
+	StatusPlaying Status = iota
+	// StatusWon means the player found the solution.
+	StatusWon
+	// StatusLost means the player ran out of attempts.
+	StatusLost
+)
+
+// String implements the Stringer interface.
+func (s Status) String() string {
+	switch s {
+	case StatusPlaying:
+		return "playing"
+	case StatusWon:
+		return "won"
+	case StatusLost:
+		return "lost"
+	default:
+		// This should never happen.
+		return "unknown"
+	}
 }
 
 // New returns a new Game, which can be used to Play!
-func New(reader io.Reader, corpus []string, maxAttempts int) (*Game, error) {
+// The reader is only used by Play, it can be nil if the game is driven by Guess.
+// Give it a list of options to tune the game.
+func New(reader io.Reader, corpus []string, maxAttempts int, opts ...Option) (*Game, error) {
 	if len(corpus) == 0 {
 		return nil, ErrCorpusIsEmpty
 	}
 	g := &Game{
-		reader:      bufio.NewReader(reader),
-		solution:    []rune(strings.ToUpper(pickWord(corpus))), // pick a random word from the corpus
+		reader:      newLineReader(reader),
 		maxAttempts: maxAttempts,
+		language:    DefaultLanguage,
+		now:         time.Now,
+		output:      os.Stdout,
+		errOutput:   os.Stderr,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	g.started = g.now()
+
+	if g.daily != nil {
+		g.solution = g.language.Normalize(dailyWord(corpus, *g.daily))
+	} else {
+		if g.rng == nil {
+			g.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		// pick a random word from the corpus
+		g.solution = g.language.Normalize(pickWordByDifficulty(corpus, g.rng, g.difficulty,
+			func(word string) float64 { return g.frequencies[word] },
+			func(word string) Difficulty { return g.difficulties[word] }))
 	}
 
+	g.logStart()
+
 	return g, nil
 }
 
-// Play runs the game.
-func (g *Game) Play() {
-	fmt.Println("Welcome to Gordle!")
+// ErrInvalidWordLength is returned when the guess and the solution have different lengths.
+var ErrInvalidWordLength = fmt.Errorf("invalid quess, word doesn't have the same number of characters as the solution")
 
-	for currentAttempt := 1; currentAttempt <= g.maxAttempts; currentAttempt++ {
-		// ask the user for a valid word
-		guess := g.ask()
+// ErrNotInDictionary is returned when the guess is not an allowed word.
+var ErrNotInDictionary = fmt.Errorf("invalid guess, word isn't in the dictionary")
 
-		fb := computeFeedback(guess, g.solution)
+// ErrHardModeViolation is returned when the guess ignores a hint in hard mode.
+var ErrHardModeViolation = fmt.Errorf("hard mode, revealed hints must be used")
 
-		fmt.Println(fb.String())
+// ErrGameOver is returned when guessing after the end of the game.
+var ErrGameOver = fmt.Errorf("game is over, no more guesses are allowed")
 
-		if slices.Equal(guess, g.solution) {
-			fmt.Printf("🎉 You won! You found it in %d guess(es)! The word was %s.\n", currentAttempt, string(g.solution))
-			return
+// ErrTimeUp is returned when guessing after the time limit. It wraps ErrGameOver.
+var ErrTimeUp = fmt.Errorf("%w: time is up", ErrGameOver)
+
+// Guess plays a word. It returns the feedback of the guess and the status of the game after it.
+// Invalid guesses don't count as an attempt: the error tells why the word was rejected.
+func (g *Game) Guess(word string) (Feedback, Status, error) {
+	if status := g.Status(); status != StatusPlaying {
+		if g.TimedOut() {
+			g.logEnd()
+			return nil, status, ErrTimeUp
 		}
+		return nil, status, ErrGameOver
 	}
 
-	fmt.Printf("😞 You've lost! The solution was: %s. \n", string(g.solution))
+	guess := g.language.Normalize(word)
+
+	err := g.validateGuess(guess)
+	if err != nil {
+		return nil, StatusPlaying, err
+	}
+
+	return g.submit(guess), g.Status(), nil
 }
 
-// ask reads input until a valid suggestion is made (and returned).
-func (g *Game) ask() []rune {
-	fmt.Printf("Enter a %d-character guess:\n", len(g.solution))
+// submit records a valid guess and returns its feedback.
+func (g *Game) submit(guess []rune) Feedback {
+	fb := computeFeedback(guess, g.solution)
+	g.record(guess, fb)
+	g.logGuess(g.history[len(g.history)-1])
+	if g.Status() != StatusPlaying {
+		g.logEnd()
+	}
 
-	for {
-		playerInput, _, err := g.reader.ReadLine()
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Gordle failed to read your guess: %s\n", err.Error())
-			continue
-		}
+	for _, observe := range g.observers {
+		observe(g, Attempt{Word: string(guess), Feedback: fb})
+	}
 
-		guess := splitToUppercaseCharacters(string(playerInput))
+	return fb
+}
 
-		err = g.validateGuess(guess)
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Your attempt is invalid with Gordle's solution! Expected %d characters, got %d.\n", len(g.solution), len(guess))
-		} else {
-			return guess
-		}
+// Status returns the status of the game.
+func (g *Game) Status() Status {
+	if n := len(g.history); n > 0 && slices.Equal(g.history[n-1].guess, g.solution) {
+		return StatusWon
+	}
+
+	if len(g.history) >= g.maxAttempts || g.timeIsUp() {
+		return StatusLost
 	}
+
+	return StatusPlaying
 }
 
-var errInvalidWordLength = fmt.Errorf("invalid quess, word doesn't have the same number of characters as the solution")
+// Attempts returns the valid guesses made so far, and their feedback.
+func (g *Game) Attempts() []Attempt {
+	attempts := make([]Attempt, len(g.history))
+	for i, a := range g.history {
+		attempts[i] = Attempt{Word: string(a.guess), Feedback: a.feedback}
+	}
+
+	return attempts
+}
+
+// MaxAttempts returns the number of guesses allowed in the game.
+func (g *Game) MaxAttempts() int {
+	return g.maxAttempts
+}
+
+// Language returns the language normalising the words of the game.
+func (g *Game) Language() Language {
+	return g.language
+}
+
+// WordLength returns the number of characters of the solution.
+func (g *Game) WordLength() int {
+	return len(g.solution)
+}
+
+// Solution returns the word to find once the game is over, and an empty string until then.
+func (g *Game) Solution() string {
+	if g.Status() == StatusPlaying {
+		return ""
+	}
+
+	return string(g.solution)
+}
 
 // validateGuess ensures the guess is a valid one
 func (g *Game) validateGuess(guess []rune) error {
 	if len(guess) != len(g.solution) {
-		return fmt.Errorf("expected %d, got %d, %w", len(g.solution), len(guess), errInvalidWordLength)
+		return fmt.Errorf("expected %d, got %d, %w", len(g.solution), len(guess), ErrInvalidWordLength)
+	}
+
+	if g.dictionary != nil && !slices.Equal(guess, g.solution) && !g.dictionary.Contains(guess) {
+		return fmt.Errorf("%q: %w", string(guess), ErrNotInDictionary)
+	}
+
+	if g.hardMode {
+		return g.validateHardMode(guess)
 	}
 
 	return nil
 }
 
-// splitToUppercaseCharacters is a naive implementation to turn a string into a list of uppercase characters.
+// validateHardMode ensures the guess keeps every correctly placed character in place
+// and contains every character found in a wrong position.
+func (g *Game) validateHardMode(guess []rune) error {
+	for _, previous := range g.history {
+		for pos, h := range previous.feedback {
+			if h == CorrectPosition && guess[pos] != previous.guess[pos] {
+				return fmt.Errorf("%w: character %d must be %c", ErrHardModeViolation, pos+1, previous.guess[pos])
+			}
+		}
+	}
+
+	for _, previous := range g.history {
+		// count how many times each character is known to be in the solution
+		required := make(map[rune]int)
+		for pos, h := range previous.feedback {
+			if h != AbsentCharacter {
+				required[previous.guess[pos]]++
+			}
+		}
+
+		for pos, h := range previous.feedback {
+			character := previous.guess[pos]
+			if h == WrongPosition && countRune(guess, character) < required[character] {
+				return fmt.Errorf("%w: guess must contain %c", ErrHardModeViolation, character)
+			}
+		}
+	}
+
+	return nil
+}
+
+// countRune returns the number of occurrences of r in word.
+func countRune(word []rune, r rune) int {
+	count := 0
+	for _, character := range word {
+		if character == r {
+			count++
+		}
+	}
+	return count
+}
+
+// splitToUppercaseCharacters turns a string into a list of uppercase characters, without language-specific rules.
 func splitToUppercaseCharacters(input string) []rune {
-	return []rune(strings.ToUpper(input))
+	return DefaultLanguage.Normalize(input)
+}
+
+// ComputeFeedback returns the feedback a guess receives against a solution, both in uppercase.
+// Tools such as solvers use it to predict the outcome of a guess.
+// Words of different lengths can't be compared: every character of the guess is then reported absent.
+func ComputeFeedback(guess, solution []rune) Feedback {
+	return computeFeedback(guess, solution)
 }
 
 // computeFeedback verifies every character of the guess against the solution.
-func computeFeedback(guess, solution []rune) feedback {
+func computeFeedback(guess, solution []rune) Feedback {
 	// initialise holders for marks
-	result := make(feedback, len(guess))
+	result := make(Feedback, len(guess))
 	used := make([]bool, len(solution))
 
 	if len(guess) != len(solution) {
-		_, _ = fmt.Fprintf(os.Stderr, "Internal error! Guess and solution have different lengths: %d vs %d", len(guess), len(solution))
-		// return a feedback full of absent characters
+		// the game validates the guesses beforehand: return a feedback full of absent characters.
 		return result
 	}
 
 	// check for correct letters
 	for posInGuess, character := range guess {
 		if character == solution[posInGuess] {
-			result[posInGuess] = correctPosition
+			result[posInGuess] = CorrectPosition
 			used[posInGuess] = true
 		}
 	}
 
 	// look for letters in the wrong position
 	for posInGuess, character := range guess {
-		if result[posInGuess] != absentCharacter {
+		if result[posInGuess] != AbsentCharacter {
 			// The character has already been marked, ignore it.
 			continue
 		}
@@ -124,7 +333,7 @@ func computeFeedback(guess, solution []rune) feedback {
 			}
 
 			if character == target {
-				result[posInGuess] = wrongPosition
+				result[posInGuess] = WrongPosition
 				used[posInSolution] = true
 				// Skip to the next letter of the guess.
 				break
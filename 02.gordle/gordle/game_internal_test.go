@@ -33,9 +33,12 @@ func TestGameAsk(t *testing.T) {
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			g, _ := New(strings.NewReader(tc.input), []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}, 0)
+			g, _ := New(strings.NewReader(tc.input), []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}, 0, WithRandSource(firstWordSource{}))
 
-			got := g.ask()
+			got, err := g.ask()
+			if err != nil {
+				t.Fatalf("ask() unexpected error %v", err)
+			}
 
 			if !slices.Equal(got, tc.want) {
 				t.Errorf("ask() got = %v, expected %v", string(got), string(tc.want))
@@ -45,7 +48,7 @@ func TestGameAsk(t *testing.T) {
 }
 
 func TestGameValidateGuess(t *testing.T) {
-	game, _ := New(strings.NewReader("smth"), []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}, 5)
+	game, _ := New(strings.NewReader("smth"), []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}, 5, WithRandSource(firstWordSource{}))
 
 	testCases := map[string]struct {
 		guess []rune
@@ -57,19 +60,19 @@ func TestGameValidateGuess(t *testing.T) {
 		},
 		"guess is shorter than desired length": {
 			guess: []rune("hi"),
-			want:  errInvalidWordLength,
+			want:  ErrInvalidWordLength,
 		},
 		"guess is longer than desired length": {
 			guess: []rune("greetings"),
-			want:  errInvalidWordLength,
+			want:  ErrInvalidWordLength,
 		},
 		"guess is empty": {
 			guess: []rune(""),
-			want:  errInvalidWordLength,
+			want:  ErrInvalidWordLength,
 		},
 		"guess is nil": {
 			guess: nil,
-			want:  errInvalidWordLength,
+			want:  ErrInvalidWordLength,
 		},
 	}
 
@@ -85,6 +88,85 @@ func TestGameValidateGuess(t *testing.T) {
 
 }
 
+func TestGameValidateGuess_Dictionary(t *testing.T) {
+	dictionary := NewDictionary([]string{"hello", "Salut", "PLANT"}, DefaultLanguage)
+	game, _ := New(strings.NewReader(""), []string{"CLOUD"}, 5, WithDictionary(dictionary))
+
+	testCases := map[string]struct {
+		guess []rune
+		want  error
+	}{
+		"guess is in the dictionary": {
+			guess: []rune("SALUT"),
+			want:  nil,
+		},
+		"guess is the solution, missing from the dictionary": {
+			guess: []rune("CLOUD"),
+			want:  nil,
+		},
+		"guess is not in the dictionary": {
+			guess: []rune("AAAAA"),
+			want:  ErrNotInDictionary,
+		},
+		"guess has the wrong length": {
+			guess: []rune("HI"),
+			want:  ErrInvalidWordLength,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := game.validateGuess(tc.guess)
+
+			if !errors.Is(err, tc.want) {
+				t.Errorf("error does not match expected type, got %v, expected %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestGameValidateGuess_HardMode(t *testing.T) {
+	game, _ := New(strings.NewReader(""), []string{"HELLO"}, 6, WithHardMode())
+	for _, guess := range []string{"HOTEL", "HALLS"} {
+		game.history = append(game.history, attempt{guess: []rune(guess), feedback: computeFeedback([]rune(guess), game.solution)})
+	}
+
+	testCases := map[string]struct {
+		guess   string
+		want    error
+		message string
+	}{
+		"every hint is used": {
+			guess: "HELLO",
+			want:  nil,
+		},
+		"correctly placed character moved": {
+			guess:   "HOLEL",
+			want:    ErrHardModeViolation,
+			message: "character 4 must be L",
+		},
+		"character in a wrong position omitted": {
+			guess:   "HULLO",
+			want:    ErrHardModeViolation,
+			message: "guess must contain E",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := game.validateGuess([]rune(tc.guess))
+
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error does not match expected type, got %v, expected %v", err, tc.want)
+			}
+
+			if err != nil && !strings.HasSuffix(err.Error(), tc.message) {
+				t.Errorf("expected error to end with %q, got %q", tc.message, err)
+			}
+		})
+	}
+}
+
 func Test_splitToUppercaseCharacters(t *testing.T) {
 	testCases := map[string]struct {
 		word     string
@@ -119,57 +201,57 @@ func Test_computeFeedback(t *testing.T) {
 	testCases := map[string]struct {
 		guess            string
 		solution         string
-		expectedFeedback feedback
+		expectedFeedback Feedback
 	}{
 		"nominal": {
 			guess:            "HERTZ",
 			solution:         "HERTZ",
-			expectedFeedback: feedback{correctPosition, correctPosition, correctPosition, correctPosition, correctPosition},
+			expectedFeedback: Feedback{CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition},
 		},
 		"double character": {
 			guess:            "HELLO",
 			solution:         "HELLO",
-			expectedFeedback: feedback{correctPosition, correctPosition, correctPosition, correctPosition, correctPosition},
+			expectedFeedback: Feedback{CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition},
 		},
 		"double character with wrong answer": {
 			guess:            "HELLL",
 			solution:         "HELLO",
-			expectedFeedback: feedback{correctPosition, correctPosition, correctPosition, correctPosition, absentCharacter},
+			expectedFeedback: Feedback{CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition, AbsentCharacter},
 		},
 		"five identical, but only two are there": {
 			guess:            "LLLLL",
 			solution:         "HELLO",
-			expectedFeedback: feedback{absentCharacter, absentCharacter, correctPosition, correctPosition, absentCharacter},
+			expectedFeedback: Feedback{AbsentCharacter, AbsentCharacter, CorrectPosition, CorrectPosition, AbsentCharacter},
 		},
 		"two identical, but not in the right position (from left to right)": {
 			guess:            "HLLEO",
 			solution:         "HELLO",
-			expectedFeedback: feedback{correctPosition, wrongPosition, correctPosition, wrongPosition, correctPosition},
+			expectedFeedback: Feedback{CorrectPosition, WrongPosition, CorrectPosition, WrongPosition, CorrectPosition},
 		},
 		"three identical, but not in the right position (from left to right)": {
 			guess:            "HLLLO",
 			solution:         "HELLO",
-			expectedFeedback: feedback{correctPosition, absentCharacter, correctPosition, correctPosition, correctPosition},
+			expectedFeedback: Feedback{CorrectPosition, AbsentCharacter, CorrectPosition, CorrectPosition, CorrectPosition},
 		},
 		"one correct, one incorrect, one absent (left of the correct)": {
 			guess:            "LLLWW",
 			solution:         "HELLO",
-			expectedFeedback: feedback{wrongPosition, absentCharacter, correctPosition, absentCharacter, absentCharacter},
+			expectedFeedback: Feedback{WrongPosition, AbsentCharacter, CorrectPosition, AbsentCharacter, AbsentCharacter},
 		},
 		"swapped characters": {
 			guess:            "HOLLE",
 			solution:         "HELLO",
-			expectedFeedback: feedback{correctPosition, wrongPosition, correctPosition, correctPosition, wrongPosition},
+			expectedFeedback: Feedback{CorrectPosition, WrongPosition, CorrectPosition, CorrectPosition, WrongPosition},
 		},
 		"absent character": {
 			guess:            "HULFO",
 			solution:         "HELFO",
-			expectedFeedback: feedback{correctPosition, absentCharacter, correctPosition, correctPosition, correctPosition},
+			expectedFeedback: Feedback{CorrectPosition, AbsentCharacter, CorrectPosition, CorrectPosition, CorrectPosition},
 		},
 		"absent character and incorrect": {
 			guess:            "HULPP",
 			solution:         "HELPO",
-			expectedFeedback: feedback{correctPosition, absentCharacter, correctPosition, correctPosition, absentCharacter},
+			expectedFeedback: Feedback{CorrectPosition, AbsentCharacter, CorrectPosition, CorrectPosition, AbsentCharacter},
 		},
 	}
 
@@ -183,3 +265,10 @@ func Test_computeFeedback(t *testing.T) {
 		})
 	}
 }
+
+// firstWordSource is a source of randomness that makes games pick the first word of their corpus.
+type firstWordSource struct{}
+
+func (firstWordSource) Int63() int64 { return 0 }
+
+func (firstWordSource) Seed(int64) {}
@@ -0,0 +1,132 @@
+package gordle_test
+
+import (
+	"errors"
+	"gordle/gordle"
+	"strings"
+	"testing"
+)
+
+func TestGame_Guess(t *testing.T) {
+	g, err := gordle.New(nil, []string{"HELLO"}, 3)
+	if err != nil {
+		t.Fatalf("unable to create game: %s", err)
+	}
+
+	fb, status, err := g.Guess("hi")
+	if !errors.Is(err, gordle.ErrInvalidWordLength) || fb != nil || status != gordle.StatusPlaying {
+		t.Errorf("expected an invalid length, got %v %v %v", fb, status, err)
+	}
+
+	fb, status, err = g.Guess("holes")
+	expected := gordle.Feedback{gordle.CorrectPosition, gordle.WrongPosition, gordle.CorrectPosition, gordle.WrongPosition, gordle.AbsentCharacter}
+	if err != nil || !expected.Equal(fb) || status != gordle.StatusPlaying {
+		t.Errorf("expected %v while playing, got %v %v %v", expected, fb, status, err)
+	}
+
+	if g.Solution() != "" {
+		t.Errorf("solution revealed before the end of the game: %q", g.Solution())
+	}
+
+	_, status, err = g.Guess("HELLO")
+	if err != nil || status != gordle.StatusWon {
+		t.Errorf("expected a win, got %v %v", status, err)
+	}
+
+	_, _, err = g.Guess("HELLO")
+	if !errors.Is(err, gordle.ErrGameOver) {
+		t.Errorf("expected %v, got %v", gordle.ErrGameOver, err)
+	}
+
+	attempts := g.Attempts()
+	if len(attempts) != 2 || attempts[0].Word != "HOLES" || attempts[1].Word != "HELLO" {
+		t.Errorf("unexpected attempts %v", attempts)
+	}
+
+	if g.Solution() != "HELLO" {
+		t.Errorf("expected the solution to be revealed, got %q", g.Solution())
+	}
+}
+
+func TestGame_GuessLost(t *testing.T) {
+	g, _ := gordle.New(nil, []string{"HELLO"}, 2)
+
+	for _, word := range []string{"WORLD", "SALUT"} {
+		_, _, err := g.Guess(word)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if g.Status() != gordle.StatusLost {
+		t.Errorf("expected %v, got %v", gordle.StatusLost, g.Status())
+	}
+}
+
+func TestGame_Play(t *testing.T) {
+	output, errOutput := &strings.Builder{}, &strings.Builder{}
+
+	g, _ := gordle.New(strings.NewReader("hi\nholes\nhello\n"), []string{"HELLO"}, 6,
+		gordle.WithOutput(output), gordle.WithErrorOutput(errOutput))
+
+	err := g.Play()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := "Welcome to Gordle!\n" +
+		"Enter a 5-character guess:\n" +
+		"💚🟡💚🟡⬜️\n" +
+		"💚 placed: H L - 🟡 present: E O - ⬜️ absent: S\n" +
+		"Enter a 5-character guess:\n" +
+		"💚💚💚💚💚\n" +
+		"🎉 You won! You found it in 2 guess(es)! The word was HELLO.\n"
+	if output.String() != expected {
+		t.Errorf("invalid output, expected %q, got %q", expected, output.String())
+	}
+
+	expectedErr := "Your attempt is invalid with Gordle's solution! Expected 5 characters, got 2.\n"
+	if errOutput.String() != expectedErr {
+		t.Errorf("invalid error output, expected %q, got %q", expectedErr, errOutput.String())
+	}
+}
+
+func TestGame_PlayEndOfInput(t *testing.T) {
+	g, _ := gordle.New(strings.NewReader("world\n"), []string{"HELLO"}, 6, gordle.WithOutput(&strings.Builder{}))
+
+	err := g.Play()
+	if err == nil {
+		t.Errorf("expected an error once the input is exhausted")
+	}
+}
+
+func TestGame_LetterStates(t *testing.T) {
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6)
+
+	for _, word := range []string{"level", "bells"} {
+		if _, _, err := g.Guess(word); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	expected := map[rune]gordle.Hint{
+		// the second E of LEVEL is absent, as the solution has a single E, but the first one is placed
+		'E': gordle.CorrectPosition,
+		// L was in a wrong position in LEVEL, then placed in BELLS
+		'L': gordle.CorrectPosition,
+		'V': gordle.AbsentCharacter,
+		'B': gordle.AbsentCharacter,
+		'S': gordle.AbsentCharacter,
+	}
+
+	got := g.LetterStates()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d letters, got %d", len(expected), len(got))
+	}
+
+	for r, h := range expected {
+		if got[r] != h {
+			t.Errorf("letter %c: expected %s, got %s", r, h, got[r])
+		}
+	}
+}
@@ -2,23 +2,26 @@ package gordle
 
 import "strings"
 
-// hint describes the validity of a character in a word.
-type hint byte
+// Hint describes the validity of a character in a word.
+type Hint byte
 
 const (
-	absentCharacter hint = iota
-	wrongPosition
-	correctPosition
+	// AbsentCharacter means the character is not in the solution.
+	AbsentCharacter Hint = iota
+	// WrongPosition means the character is in the solution, elsewhere.
+	WrongPosition
+	// CorrectPosition means the character is in the solution, at this position.
+	CorrectPosition
 )
 
 // String implements the Stringer interface.
-func (h hint) String() string {
+func (h Hint) String() string {
 	switch h {
-	case absentCharacter:
+	case AbsentCharacter:
 		return "⬜️"
-	case wrongPosition:
+	case WrongPosition:
 		return "🟡"
-	case correctPosition:
+	case CorrectPosition:
 		return "💚"
 	default:
 		// This should never happen.
@@ -26,10 +29,11 @@ func (h hint) String() string {
 	}
 }
 
-// feedback is a list of hints, one per character of the word.
-type feedback []hint
+// Feedback is a list of hints, one per character of the word.
+type Feedback []Hint
 
-func (fb feedback) String() string {
+// String implements the Stringer interface.
+func (fb Feedback) String() string {
 	sb := strings.Builder{}
 	for _, h := range fb {
 		sb.WriteString(h.String())
@@ -38,7 +42,7 @@ func (fb feedback) String() string {
 }
 
 // Equal determines equality of two feedbacks.
-func (fb feedback) Equal(other feedback) bool {
+func (fb Feedback) Equal(other Feedback) bool {
 	if len(fb) != len(other) {
 		return false
 	}
@@ -4,18 +4,18 @@ import "testing"
 
 func TestFeedback_String(t *testing.T) {
 	testCases := map[string]struct {
-		feedback feedback
+		feedback Feedback
 		expected string
 	}{
 		"all correct hints": {
-			feedback: []hint{correctPosition, correctPosition, correctPosition},
+			feedback: []Hint{CorrectPosition, CorrectPosition, CorrectPosition},
 			expected: "💚💚💚",
 		}, "various hints": {
-			feedback: []hint{wrongPosition, correctPosition, absentCharacter},
+			feedback: []Hint{WrongPosition, CorrectPosition, AbsentCharacter},
 			expected: "🟡💚⬜️",
 		},
 		"invalid hint": {
-			feedback: []hint{42},
+			feedback: []Hint{42},
 			expected: "💔",
 		},
 	}
@@ -0,0 +1,90 @@
+package gordle
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrUnknownLanguage is returned when no language has the requested code.
+const ErrUnknownLanguage = languageError("unknown language")
+
+// languageError defines a sentinel error.
+type languageError string
+
+// Error is the implementation of the error interface by languageError
+func (e languageError) Error() string {
+	return string(e)
+}
+
+// Language holds the rules turning a word, from the corpus or from a player, into the characters compared by the game.
+// Words are always put in Unicode normalisation form C, so that "É" typed as E and a combining accent matches "É",
+// then uppercased with the rules of the language.
+type Language struct {
+	// Code identifies the language, such as "fr". It is empty for DefaultLanguage.
+	Code string
+	tag  language.Tag
+	// foldAccents removes the diacritics of letters, except those listed in preserved,
+	// which are letters of their own in the language.
+	foldAccents bool
+	preserved   string
+}
+
+var (
+	// DefaultLanguage uppercases words without language-specific rules, and keeps accents.
+	DefaultLanguage = Language{tag: language.Und}
+	// English ignores the accents of borrowed words, such as CAFÉ.
+	English = Language{Code: "en", tag: language.English, foldAccents: true}
+	// French ignores accents: É, È and Ê all match E.
+	French = Language{Code: "fr", tag: language.French, foldAccents: true}
+	// Spanish ignores accents, but Ñ is a letter of its own.
+	Spanish = Language{Code: "es", tag: language.Spanish, foldAccents: true, preserved: "Ñ"}
+	// German keeps umlauts, and uppercases ß as SS.
+	German = Language{Code: "de", tag: language.German}
+	// Turkish keeps the dotted İ and the dotless I apart.
+	Turkish = Language{Code: "tr", tag: language.Turkish}
+)
+
+// languages lists the supported languages.
+var languages = []Language{English, French, Spanish, German, Turkish}
+
+// LanguageByCode returns the language with the given code, such as "fr".
+func LanguageByCode(code string) (Language, error) {
+	for _, l := range languages {
+		if l.Code == strings.ToLower(code) {
+			return l, nil
+		}
+	}
+
+	return Language{}, fmt.Errorf("%q: %w", code, ErrUnknownLanguage)
+}
+
+// Normalize returns the characters of the word, as compared by the game.
+func (l Language) Normalize(word string) []rune {
+	upper := cases.Upper(l.tag).String(norm.NFC.String(word))
+	if !l.foldAccents {
+		return []rune(upper)
+	}
+
+	folded := make([]rune, 0, len(upper))
+	for _, r := range upper {
+		if strings.ContainsRune(l.preserved, r) {
+			folded = append(folded, r)
+			continue
+		}
+
+		// decompose the letter, and drop its diacritics
+		for _, part := range norm.NFD.String(string(r)) {
+			if !unicode.Is(unicode.Mn, part) {
+				folded = append(folded, part)
+			}
+		}
+	}
+
+	// put back together what the decomposition split apart, such as Hangul syllables
+	return []rune(norm.NFC.String(string(folded)))
+}
@@ -0,0 +1,115 @@
+package gordle_test
+
+import (
+	"errors"
+	"gordle/gordle"
+	"testing"
+)
+
+func TestLanguage_Normalize(t *testing.T) {
+	testCases := map[string]struct {
+		language gordle.Language
+		word     string
+		expected string
+	}{
+		"default keeps accents": {
+			language: gordle.DefaultLanguage,
+			word:     "école",
+			expected: "ÉCOLE",
+		},
+		"English ignores accents": {
+			language: gordle.English,
+			word:     "café",
+			expected: "CAFE",
+		},
+		"French ignores accents": {
+			language: gordle.French,
+			word:     "élève",
+			expected: "ELEVE",
+		},
+		"French combining accent": {
+			language: gordle.French,
+			word:     "école",
+			expected: "ECOLE",
+		},
+		"default composes combining accent": {
+			language: gordle.DefaultLanguage,
+			word:     "école",
+			expected: "ÉCOLE",
+		},
+		"Spanish keeps Ñ": {
+			language: gordle.Spanish,
+			word:     "cañón",
+			expected: "CAÑON",
+		},
+		"Spanish composes combining tilde": {
+			language: gordle.Spanish,
+			word:     "niño",
+			expected: "NIÑO",
+		},
+		"German uppercases ß": {
+			language: gordle.German,
+			word:     "straße",
+			expected: "STRASSE",
+		},
+		"German keeps umlauts": {
+			language: gordle.German,
+			word:     "bäume",
+			expected: "BÄUME",
+		},
+		"Turkish dotted i": {
+			language: gordle.Turkish,
+			word:     "kişi",
+			expected: "KİŞİ",
+		},
+		"Turkish dotless i": {
+			language: gordle.Turkish,
+			word:     "ılık",
+			expected: "ILIK",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := string(tc.language.Normalize(tc.word))
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLanguageByCode(t *testing.T) {
+	l, err := gordle.LanguageByCode("FR")
+	if err != nil || l.Code != "fr" {
+		t.Errorf("expected French, got %q and error %v", l.Code, err)
+	}
+
+	_, err = gordle.LanguageByCode("xx")
+	if !errors.Is(err, gordle.ErrUnknownLanguage) {
+		t.Errorf("expected %v, got %v", gordle.ErrUnknownLanguage, err)
+	}
+}
+
+func TestGame_Guess_language(t *testing.T) {
+	dictionary := gordle.NewDictionary([]string{"ÉLÈVE", "FORÊT"}, gordle.French)
+	g, err := gordle.New(nil, []string{"élève"}, 6, gordle.WithLanguage(gordle.French), gordle.WithDictionary(dictionary))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the dictionary lists FORÊT, which the player may type without its accent
+	_, status, err := g.Guess("foret")
+	if err != nil || status != gordle.StatusPlaying {
+		t.Fatalf("expected the guess to be accepted, got status %s and error %v", status, err)
+	}
+
+	_, status, err = g.Guess("eleve")
+	if err != nil || status != gordle.StatusWon {
+		t.Errorf("expected the accents to be ignored, got status %s and error %v", status, err)
+	}
+
+	if g.Solution() != "ELEVE" {
+		t.Errorf("unexpected solution %q", g.Solution())
+	}
+}
@@ -0,0 +1,68 @@
+package gordle
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// record adds a guess and its feedback to the history, and updates the best known hint of its characters.
+func (g *Game) record(guess []rune, fb Feedback) {
+	g.history = append(g.history, attempt{guess: guess, feedback: fb, at: g.now()})
+
+	if g.letters == nil {
+		g.letters = make(map[rune]Hint)
+	}
+
+	for pos, character := range guess {
+		// hints are ordered from the least to the most informative: a character correctly placed
+		// somewhere stays so, even if another occurrence of it in the guess is absent.
+		if h, ok := g.letters[character]; !ok || fb[pos] > h {
+			g.letters[character] = fb[pos]
+		}
+	}
+}
+
+// LetterStates returns the best hint received by every character played so far.
+// A character is CorrectPosition if it was placed once, WrongPosition if it's known to be in the solution,
+// and AbsentCharacter if it isn't. Characters that were never played are missing from the map.
+func (g *Game) LetterStates() map[rune]Hint {
+	letters := make(map[rune]Hint, len(g.letters))
+	for character, h := range g.letters {
+		letters[character] = h
+	}
+
+	return letters
+}
+
+// letterSummary describes the characters played so far, grouped by their best hint.
+func (g *Game) letterSummary() string {
+	groups := make(map[Hint][]rune)
+	for character, h := range g.letters {
+		groups[h] = append(groups[h], character)
+	}
+
+	sb := strings.Builder{}
+	for _, group := range []struct {
+		hint Hint
+		name string
+	}{
+		{hint: CorrectPosition, name: "placed"},
+		{hint: WrongPosition, name: "present"},
+		{hint: AbsentCharacter, name: "absent"},
+	} {
+		characters := groups[group.hint]
+		if len(characters) == 0 {
+			continue
+		}
+
+		slices.Sort(characters)
+		if sb.Len() > 0 {
+			sb.WriteString(" - ")
+		}
+		_, _ = fmt.Fprintf(&sb, "%s %s: %s", group.hint, group.name, strings.Join(strings.Split(string(characters), ""), " "))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+package gordle
+
+import (
+	"io"
+	"math/rand"
+	"time"
+)
+
+// Option defines a functional option to a Game.
+type Option func(*Game)
+
+// WithDictionary restricts the guesses to the words of the dictionary.
+// The solution is always accepted, even if the dictionary doesn't contain it.
+func WithDictionary(d Dictionary) Option {
+	return func(g *Game) {
+		g.dictionary = &d
+	}
+}
+
+// WithLanguage sets the rules normalising the words of the corpus and the guesses.
+// The dictionary, if any, must be built for the same language.
+func WithLanguage(l Language) Option {
+	return func(g *Game) {
+		g.language = l
+	}
+}
+
+// WithHardMode requires every guess to use the hints revealed by the previous ones:
+// correctly placed characters must stay in place, and characters in a wrong position must be reused.
+func WithHardMode() Option {
+	return func(g *Game) {
+		g.hardMode = true
+	}
+}
+
+// WithOutput sets where Play writes the game. The default is os.Stdout.
+func WithOutput(output io.Writer) Option {
+	return func(g *Game) {
+		g.output = output
+	}
+}
+
+// WithErrorOutput sets where Play writes the reasons guesses are rejected. The default is os.Stderr.
+func WithErrorOutput(errOutput io.Writer) Option {
+	return func(g *Game) {
+		g.errOutput = errOutput
+	}
+}
+
+// WithSeed makes the choice of the solution reproducible: games created with the same seed and corpus have the same solution.
+func WithSeed(seed int64) Option {
+	return WithRandSource(rand.NewSource(seed))
+}
+
+// WithRandSource sets the source of randomness used to pick the solution.
+func WithRandSource(src rand.Source) Option {
+	return func(g *Game) {
+		g.rng = rand.New(src)
+	}
+}
+
+// WithDifficulty biases the choice of the solution with the frequency of the words, keyed by their spelling in the corpus:
+// easy games favour common words, and hard games rare ones. Words missing from the frequencies are considered rare.
+// The word of the day ignores the difficulty, as every player must get the same word.
+func WithDifficulty(d Difficulty, frequencies map[string]float64) Option {
+	return func(g *Game) {
+		g.difficulty = d
+		g.frequencies = frequencies
+	}
+}
+
+// WithWordDifficulties sets the difficulty of the words, keyed by their spelling in the corpus, such as the tags of a corpus file.
+// When none of the words has a known frequency, WithDifficulty only picks the words of its difficulty, if there are any.
+func WithWordDifficulties(difficulties map[string]Difficulty) Option {
+	return func(g *Game) {
+		g.difficulties = difficulties
+	}
+}
+
+// WithDailyWord derives the solution from the date, ignoring the time and location of the day:
+// every game created on the same day, with the same corpus, has the same solution.
+func WithDailyWord(date time.Time) Option {
+	return func(g *Game) {
+		g.daily = &date
+	}
+}
+
+// WithClock sets the function reading the time, which times the game. The default is time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(g *Game) {
+		g.now = now
+	}
+}
+
+// WithTimeLimit ends the game, lost, once the duration has passed since its creation.
+func WithTimeLimit(d time.Duration) Option {
+	return func(g *Game) {
+		g.gameTimeLimit = d
+	}
+}
+
+// WithGuessTimeLimit ends the game, lost, if the player doesn't make a valid guess within the duration
+// after the previous one, or after the creation of the game for the first guess.
+func WithGuessTimeLimit(d time.Duration) Option {
+	return func(g *Game) {
+		g.guessTimeLimit = d
+	}
+}
+
+// Observer is notified of every valid guess, once its feedback is known.
+type Observer func(g *Game, a Attempt)
+
+// WithObserver registers an observer, called after every valid guess made through Play or Guess.
+func WithObserver(observer Observer) Option {
+	return func(g *Game) {
+		g.observers = append(g.observers, observer)
+	}
+}
+
+// WithEventLog writes the events of the game to w, as JSON lines: its start, every valid guess and its end.
+// Several games can be written to the same log, and read back with ReadReplays.
+func WithEventLog(w io.Writer) Option {
+	return func(g *Game) {
+		g.eventLog = w
+	}
+}
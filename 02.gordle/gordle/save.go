@@ -0,0 +1,175 @@
+package gordle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// saveVersion is the version of the format written by Save.
+const saveVersion = 1
+
+// savedGame is the representation of a game written by Save.
+type savedGame struct {
+	Version int `json:"version"`
+	// Solution is obfuscated, so that it can't be read at a glance.
+	Solution    string         `json:"solution"`
+	MaxAttempts int            `json:"maxAttempts"`
+	HardMode    bool           `json:"hardMode"`
+	Language    string         `json:"language,omitempty"`
+	Attempts    []savedAttempt `json:"attempts"`
+	// Elapsed is the time spent playing when the game was saved, so that the time limits go on where they stopped.
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+}
+
+// savedAttempt is the representation of an attempt written by Save.
+type savedAttempt struct {
+	Guess    string `json:"guess"`
+	Feedback []int  `json:"feedback"`
+	// Elapsed is the time between the start of the game and the guess.
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+}
+
+// Save writes the state of the game to w, so that it can be resumed with Load.
+// The dictionary, outputs and observers are not saved: they are options given to Load.
+func (g *Game) Save(w io.Writer) error {
+	saved := savedGame{
+		Version:     saveVersion,
+		Solution:    obfuscate(string(g.solution)),
+		MaxAttempts: g.maxAttempts,
+		HardMode:    g.hardMode,
+		Language:    g.language.Code,
+		Attempts:    make([]savedAttempt, len(g.history)),
+		Elapsed:     g.Duration(),
+	}
+
+	for i, a := range g.history {
+		hints := make([]int, len(a.feedback))
+		for j, h := range a.feedback {
+			hints[j] = int(h)
+		}
+		saved.Attempts[i] = savedAttempt{Guess: string(a.guess), Feedback: hints, Elapsed: a.at.Sub(g.started)}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(saved)
+	if err != nil {
+		return fmt.Errorf("unable to save game: %w", err)
+	}
+
+	return nil
+}
+
+// Load restores a game written by Save. The reader is used by Play, as in New.
+// The options are applied before the saved state, which takes precedence over them.
+func Load(save io.Reader, reader io.Reader, opts ...Option) (*Game, error) {
+	var saved savedGame
+	err := json.NewDecoder(save).Decode(&saved)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSave, err)
+	}
+
+	if saved.Version != saveVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSave, saved.Version)
+	}
+
+	if saved.MaxAttempts < 1 {
+		return nil, fmt.Errorf("%w: invalid number of attempts %d", ErrInvalidSave, saved.MaxAttempts)
+	}
+
+	if len(saved.Attempts) > saved.MaxAttempts {
+		return nil, fmt.Errorf("%w: %d guesses made out of %d attempts", ErrInvalidSave, len(saved.Attempts), saved.MaxAttempts)
+	}
+
+	if saved.Elapsed < 0 {
+		return nil, fmt.Errorf("%w: invalid elapsed time %s", ErrInvalidSave, saved.Elapsed)
+	}
+
+	solution, err := deobfuscate(saved.Solution)
+	if err != nil || solution == "" {
+		return nil, fmt.Errorf("%w: unreadable solution", ErrInvalidSave)
+	}
+
+	lang := DefaultLanguage
+	if saved.Language != "" {
+		lang, err = LanguageByCode(saved.Language)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSave, err)
+		}
+	}
+
+	g := &Game{
+		reader:    newLineReader(reader),
+		now:       time.Now,
+		output:    os.Stdout,
+		errOutput: os.Stderr,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	// the time limits, if any, go on where they stopped: the time between the save and now doesn't count.
+	g.started = g.now().Add(-saved.Elapsed)
+
+	g.solution = []rune(solution)
+	g.maxAttempts = saved.MaxAttempts
+	g.hardMode = saved.HardMode
+	g.language = lang
+
+	for _, a := range saved.Attempts {
+		guess := []rune(a.Guess)
+		fb := make(Feedback, len(a.Feedback))
+		for i, h := range a.Feedback {
+			fb[i] = Hint(h)
+		}
+
+		if len(guess) != len(g.solution) || !computeFeedback(guess, g.solution).Equal(fb) {
+			return nil, fmt.Errorf("%w: feedback of %q doesn't match the solution", ErrInvalidSave, a.Guess)
+		}
+
+		if a.Elapsed < 0 || a.Elapsed > saved.Elapsed {
+			return nil, fmt.Errorf("%w: invalid elapsed time %s for %q", ErrInvalidSave, a.Elapsed, a.Guess)
+		}
+
+		g.record(guess, fb)
+		g.history[len(g.history)-1].at = g.started.Add(a.Elapsed)
+	}
+
+	g.logResume()
+
+	return g, nil
+}
+
+// obfuscationKey scrambles the solution in saved games.
+// This is not encryption: it only prevents players from spoiling the game by opening the file.
+const obfuscationKey = "gordle"
+
+// obfuscate scrambles a word.
+func obfuscate(word string) string {
+	return base64.StdEncoding.EncodeToString(xor([]byte(word)))
+}
+
+// deobfuscate reverts obfuscate.
+func deobfuscate(scrambled string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(scrambled)
+	if err != nil {
+		return "", err
+	}
+
+	return string(xor(data)), nil
+}
+
+// xor combines data with the obfuscation key. Applying it twice returns the original data.
+func xor(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		result[i] = b ^ obfuscationKey[i%len(obfuscationKey)]
+	}
+	return result
+}
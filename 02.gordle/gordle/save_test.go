@@ -0,0 +1,112 @@
+package gordle_test
+
+import (
+	"bytes"
+	"errors"
+	"gordle/gordle"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGame_SaveLoad(t *testing.T) {
+	g, _ := gordle.New(nil, []string{"ΧΑΙΡΕ"}, 4, gordle.WithHardMode())
+	_, _, err := g.Guess("ΧΡΑΙΕ")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	save := &bytes.Buffer{}
+	err = g.Save(save)
+	if err != nil {
+		t.Fatalf("unable to save: %s", err)
+	}
+
+	if strings.Contains(save.String(), "ΧΑΙΡΕ") {
+		t.Errorf("the solution can be read from the save: %s", save.String())
+	}
+
+	loaded, err := gordle.Load(save, nil)
+	if err != nil {
+		t.Fatalf("unable to load: %s", err)
+	}
+
+	attempts := loaded.Attempts()
+	if len(attempts) != 1 || attempts[0].Word != "ΧΡΑΙΕ" || !attempts[0].Feedback.Equal(g.Attempts()[0].Feedback) {
+		t.Errorf("unexpected attempts %v", attempts)
+	}
+
+	if loaded.MaxAttempts() != 4 {
+		t.Errorf("expected 4 attempts, got %d", loaded.MaxAttempts())
+	}
+
+	// hard mode was restored
+	_, _, err = loaded.Guess("ΑΑΑΑΑ")
+	if !errors.Is(err, gordle.ErrHardModeViolation) {
+		t.Errorf("expected %v, got %v", gordle.ErrHardModeViolation, err)
+	}
+
+	_, status, err := loaded.Guess("χαιρε")
+	if err != nil || status != gordle.StatusWon {
+		t.Errorf("expected to win, got %v (err %v)", status, err)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	testCases := map[string]string{
+		"not json":          "{",
+		"unknown version":   `{"version": 99}`,
+		"missing solution":  `{"version": 1, "maxAttempts": 6}`,
+		"tampered feedback": `{"version": 1, "solution": "Lyo+KCM=", "maxAttempts": 6, "attempts": [{"guess": "HELLO", "feedback": [0, 0, 0, 0, 0]}]}`,
+		"no attempts":       `{"version": 1, "solution": "Lyo+KCM=", "maxAttempts": 0}`,
+		"too many guesses":  `{"version": 1, "solution": "Lyo+KCM=", "maxAttempts": 1, "attempts": [{"guess": "WORLD", "feedback": [0, 1, 0, 2, 0]}, {"guess": "WORLD", "feedback": [0, 1, 0, 2, 0]}]}`,
+		"negative elapsed":  `{"version": 1, "solution": "Lyo+KCM=", "maxAttempts": 6, "elapsed": -1}`,
+		"guess after save":  `{"version": 1, "solution": "Lyo+KCM=", "maxAttempts": 6, "attempts": [{"guess": "WORLD", "feedback": [0, 1, 0, 2, 0], "elapsed": 2}], "elapsed": 1}`,
+	}
+
+	for name, save := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := gordle.Load(strings.NewReader(save), nil)
+			if !errors.Is(err, gordle.ErrInvalidSave) {
+				t.Errorf("expected %v, got %v", gordle.ErrInvalidSave, err)
+			}
+		})
+	}
+}
+
+func TestGame_SaveLoadTimeLimits(t *testing.T) {
+	clock := newFakeClock()
+	opts := []gordle.Option{gordle.WithClock(clock.Now), gordle.WithTimeLimit(time.Minute), gordle.WithGuessTimeLimit(30 * time.Second)}
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, opts...)
+
+	clock.advance(20 * time.Second)
+	if _, _, err := g.Guess("world"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	clock.advance(5 * time.Second)
+
+	save := &bytes.Buffer{}
+	if err := g.Save(save); err != nil {
+		t.Fatalf("unable to save: %s", err)
+	}
+
+	// the time between the save and the resumption doesn't count
+	clock.advance(time.Hour)
+	loaded, err := gordle.Load(save, nil, opts...)
+	if err != nil {
+		t.Fatalf("unable to load: %s", err)
+	}
+
+	// 25 seconds of the guess time limit are left, before the 35 seconds of the game time limit
+	if remaining, ok := loaded.Remaining(); !ok || remaining != 25*time.Second {
+		t.Errorf("expected 25s left, got %s", remaining)
+	}
+	if loaded.Duration() != 25*time.Second {
+		t.Errorf("expected 25s played, got %s", loaded.Duration())
+	}
+
+	clock.advance(25 * time.Second)
+	if !loaded.TimedOut() {
+		t.Errorf("expected the game to be lost on time, got %s", loaded.Status())
+	}
+}
@@ -0,0 +1,60 @@
+package gordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Palette holds the symbols representing each hint in a shared result.
+type Palette struct {
+	Absent  string
+	Wrong   string
+	Correct string
+}
+
+// DefaultPalette uses the same symbols as the feedback printed during the game.
+var DefaultPalette = Palette{Absent: AbsentCharacter.String(), Wrong: WrongPosition.String(), Correct: CorrectPosition.String()}
+
+// HighContrastPalette is easier to read for colour-blind players.
+var HighContrastPalette = Palette{Absent: "⬛", Wrong: "🟦", Correct: "🟧"}
+
+// symbol returns the representation of a hint.
+func (p Palette) symbol(h Hint) string {
+	switch h {
+	case AbsentCharacter:
+		return p.Absent
+	case WrongPosition:
+		return p.Wrong
+	case CorrectPosition:
+		return p.Correct
+	default:
+		// This should never happen.
+		return h.String()
+	}
+}
+
+// Share returns a spoiler-free summary of the game, to be pasted in a chat:
+// a "Gordle #N 4/6" header, followed by one row of symbols per attempt, without any letter.
+// A lost game is scored X, and hard mode is marked with a star.
+func (g *Game) Share(number int, palette Palette) string {
+	score := "X"
+	if g.Status() == StatusWon {
+		score = fmt.Sprint(len(g.history))
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Gordle #%d %s/%d", number, score, g.maxAttempts))
+	if g.hardMode {
+		sb.WriteString("*")
+	}
+	sb.WriteString("\n\n")
+
+	for _, a := range g.history {
+		for _, h := range a.feedback {
+			sb.WriteString(palette.symbol(h))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+package gordle_test
+
+import (
+	"gordle/gordle"
+	"testing"
+)
+
+func TestGame_Share(t *testing.T) {
+	testCases := map[string]struct {
+		guesses  []string
+		opts     []gordle.Option
+		palette  gordle.Palette
+		expected string
+	}{
+		"won": {
+			guesses:  []string{"HOLES", "HELLO"},
+			palette:  gordle.DefaultPalette,
+			expected: "Gordle #42 2/6\n\n💚🟡💚🟡⬜️\n💚💚💚💚💚\n",
+		},
+		"won in hard mode with high contrast": {
+			guesses:  []string{"HOLES", "HELLO"},
+			opts:     []gordle.Option{gordle.WithHardMode()},
+			palette:  gordle.HighContrastPalette,
+			expected: "Gordle #42 2/6*\n\n🟧🟦🟧🟦⬛\n🟧🟧🟧🟧🟧\n",
+		},
+		"lost": {
+			guesses:  []string{"WORLD", "WORLD", "WORLD", "WORLD", "WORLD", "WORLD"},
+			palette:  gordle.HighContrastPalette,
+			expected: "Gordle #42 X/6\n\n" + "⬛🟦⬛🟧⬛\n⬛🟦⬛🟧⬛\n⬛🟦⬛🟧⬛\n⬛🟦⬛🟧⬛\n⬛🟦⬛🟧⬛\n⬛🟦⬛🟧⬛\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			g, _ := gordle.New(nil, []string{"HELLO"}, 6, tc.opts...)
+			for _, guess := range tc.guesses {
+				_, _, err := g.Guess(guess)
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+			}
+
+			if got := g.Share(42, tc.palette); got != tc.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package gordle
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotEnoughWords is returned when a speedrun needs more words than the corpus has.
+const ErrNotEnoughWords = corpusError("not enough words in the corpus")
+
+// SpeedrunResult summarises a speedrun.
+type SpeedrunResult struct {
+	// Games is the number of games played, and Won the number of words found.
+	Games int
+	Won   int
+	// Guesses is the total number of valid guesses.
+	Guesses int
+	// Duration is the total time spent playing the games.
+	Duration time.Duration
+}
+
+// Speedrun plays games in a row in the terminal, each with a different word of the corpus, and returns the total.
+// The options apply to every game: a time limit, for instance, limits each game.
+// It stops early if the reader fails, returning the result of the games played so far.
+func Speedrun(reader io.Reader, corpus []string, maxAttempts, games int, opts ...Option) (SpeedrunResult, error) {
+	if maxAttempts < 1 {
+		return SpeedrunResult{}, fmt.Errorf("invalid number of attempts %d, expected at least 1", maxAttempts)
+	}
+
+	if games > len(corpus) {
+		return SpeedrunResult{}, fmt.Errorf("%w: %d words for %d games", ErrNotEnoughWords, len(corpus), games)
+	}
+
+	// the games share the buffer of the reader, which New would otherwise lose between games,
+	// and the line the player was typing when the time of the previous game was up.
+	lines := newLineReader(reader)
+	remaining := corpus
+
+	var result SpeedrunResult
+	for i := 0; i < games; i++ {
+		g, err := New(nil, remaining, maxAttempts, opts...)
+		if err != nil {
+			return result, err
+		}
+		g.reader = lines
+
+		_, _ = fmt.Fprintf(g.output, "Word %d of %d\n", i+1, games)
+		err = g.Play()
+		if err != nil {
+			return result, err
+		}
+
+		result.Games++
+		result.Guesses += len(g.history)
+		result.Duration += g.Duration()
+		if g.Status() == StatusWon {
+			result.Won++
+		}
+
+		remaining = withoutWord(remaining, g.solution, g.language)
+	}
+
+	return result, nil
+}
+
+// withoutWord returns the words of the corpus but the given one.
+func withoutWord(corpus []string, word []rune, l Language) []string {
+	words := make([]string, 0, len(corpus))
+	for _, w := range corpus {
+		if string(l.Normalize(w)) != string(word) {
+			words = append(words, w)
+		}
+	}
+
+	return words
+}
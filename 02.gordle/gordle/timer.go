@@ -0,0 +1,89 @@
+package gordle
+
+import (
+	"time"
+)
+
+// deadline returns the time the game ends unless the player finds the solution, and whether the game has a time limit.
+// It's the earliest of the end of the game time limit, and the end of the time allowed for the next guess.
+func (g *Game) deadline() (time.Time, bool) {
+	var deadline time.Time
+	if g.gameTimeLimit > 0 {
+		deadline = g.started.Add(g.gameTimeLimit)
+	}
+
+	if g.guessTimeLimit > 0 {
+		last := g.started
+		if n := len(g.history); n > 0 {
+			last = g.history[n-1].at
+		}
+
+		if guessDeadline := last.Add(g.guessTimeLimit); deadline.IsZero() || guessDeadline.Before(deadline) {
+			deadline = guessDeadline
+		}
+	}
+
+	return deadline, !deadline.IsZero()
+}
+
+// timeIsUp tells whether the deadline of the game has passed.
+func (g *Game) timeIsUp() bool {
+	deadline, ok := g.deadline()
+	return ok && !g.now().Before(deadline)
+}
+
+// TimedOut tells whether the game was lost because time ran out.
+func (g *Game) TimedOut() bool {
+	return g.Status() == StatusLost && len(g.history) < g.maxAttempts
+}
+
+// EndOnTimeUp ends the game if its time is up: the end of the game is written to the event log, as when a word is played too late.
+// Interfaces waiting for the time limit on their own, such as a full screen UI, call it once the game is over.
+func (g *Game) EndOnTimeUp() {
+	if g.TimedOut() {
+		g.logEnd()
+	}
+}
+
+// Timer returns a channel receiving the time once the game's time is up, nil if it has no time limit,
+// and the function releasing the timer. The deadline moves with every guess: call it again after each one.
+func (g *Game) Timer() (<-chan time.Time, func()) {
+	remaining, ok := g.Remaining()
+	if !ok {
+		return nil, func() {}
+	}
+
+	t := time.NewTimer(remaining)
+	return t.C, func() { t.Stop() }
+}
+
+// Remaining returns the time left before the game ends, and whether the game has a time limit.
+func (g *Game) Remaining() (time.Duration, bool) {
+	deadline, ok := g.deadline()
+	if !ok {
+		return 0, false
+	}
+
+	remaining := deadline.Sub(g.now())
+	if remaining < 0 || g.Status() != StatusPlaying {
+		return 0, true
+	}
+
+	return remaining, true
+}
+
+// Duration returns the time spent playing the game: until the last guess, or the deadline, once it's over.
+func (g *Game) Duration() time.Duration {
+	switch {
+	case g.TimedOut():
+		deadline, _ := g.deadline()
+		return deadline.Sub(g.started)
+	case g.Status() != StatusPlaying && len(g.history) == 0:
+		// a game without attempts is over before it starts
+		return 0
+	case g.Status() != StatusPlaying:
+		return g.history[len(g.history)-1].at.Sub(g.started)
+	default:
+		return g.now().Sub(g.started)
+	}
+}
@@ -0,0 +1,208 @@
+package gordle_test
+
+import (
+	"errors"
+	"gordle/gordle"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2022, 12, 3, 12, 0, 0, 0, time.UTC)}
+}
+
+// Now returns the time of the clock.
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// advance moves the clock forward.
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestGame_TimeLimit(t *testing.T) {
+	clock := newFakeClock()
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithClock(clock.Now), gordle.WithTimeLimit(time.Minute))
+
+	clock.advance(40 * time.Second)
+	if _, _, err := g.Guess("world"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if remaining, ok := g.Remaining(); !ok || remaining != 20*time.Second {
+		t.Errorf("expected 20s left, got %s", remaining)
+	}
+
+	clock.advance(20 * time.Second)
+	if g.Status() != gordle.StatusLost || !g.TimedOut() {
+		t.Errorf("expected the game to be lost on time, got %s", g.Status())
+	}
+
+	_, _, err := g.Guess("hello")
+	if !errors.Is(err, gordle.ErrTimeUp) || !errors.Is(err, gordle.ErrGameOver) {
+		t.Errorf("expected %v, got %v", gordle.ErrTimeUp, err)
+	}
+
+	if g.Duration() != time.Minute {
+		t.Errorf("expected the game to last a minute, got %s", g.Duration())
+	}
+}
+
+func TestGame_GuessTimeLimit(t *testing.T) {
+	clock := newFakeClock()
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithClock(clock.Now), gordle.WithGuessTimeLimit(10*time.Second))
+
+	// every guess gives the player 10 more seconds
+	for _, word := range []string{"world", "plant", "salut"} {
+		clock.advance(9 * time.Second)
+		if _, _, err := g.Guess(word); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	clock.advance(10 * time.Second)
+	if !g.TimedOut() {
+		t.Errorf("expected the game to be lost on time, got %s", g.Status())
+	}
+
+	if g.Duration() != 37*time.Second {
+		t.Errorf("expected the game to last 37s, got %s", g.Duration())
+	}
+}
+
+func TestGame_Duration(t *testing.T) {
+	clock := newFakeClock()
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6, gordle.WithClock(clock.Now))
+
+	if _, ok := g.Remaining(); ok {
+		t.Errorf("expected no time limit")
+	}
+
+	clock.advance(5 * time.Second)
+	_, _, _ = g.Guess("hello")
+	clock.advance(time.Hour)
+
+	if g.TimedOut() || g.Duration() != 5*time.Second {
+		t.Errorf("expected a game won in 5s, got %s", g.Duration())
+	}
+}
+
+func TestGame_PlayTimeUp(t *testing.T) {
+	clock := newFakeClock()
+	output := &strings.Builder{}
+
+	// the player takes too long to type the guess, the clock moves while reading it
+	reader := readerFunc(func(p []byte) (int, error) {
+		clock.advance(time.Minute)
+		return copy(p, "hello\n"), nil
+	})
+
+	g, _ := gordle.New(reader, []string{"HELLO"}, 6,
+		gordle.WithClock(clock.Now), gordle.WithTimeLimit(30*time.Second), gordle.WithOutput(output))
+
+	err := g.Play()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := "Welcome to Gordle!\n" +
+		"Enter a 5-character guess (30s left):\n" +
+		"⏰ Time's up! The solution was: HELLO.\n"
+	if output.String() != expected {
+		t.Errorf("invalid output, expected %q, got %q", expected, output.String())
+	}
+}
+
+func TestGame_PlayIdle(t *testing.T) {
+	output := &strings.Builder{}
+
+	// the player never types anything
+	idle, typing := io.Pipe()
+	defer typing.Close()
+
+	g, _ := gordle.New(idle, []string{"HELLO"}, 6, gordle.WithTimeLimit(50*time.Millisecond), gordle.WithOutput(output))
+
+	done := make(chan error)
+	go func() { done <- g.Play() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the game to end when the time is up")
+	}
+
+	if !g.TimedOut() || !strings.HasSuffix(output.String(), "⏰ Time's up! The solution was: HELLO.\n") {
+		t.Errorf("expected the game to be lost on time, got %q", output.String())
+	}
+}
+
+func TestGame_DurationWithoutAttempts(t *testing.T) {
+	g, _ := gordle.New(nil, []string{"HELLO"}, 0)
+
+	if g.Status() != gordle.StatusLost || g.Duration() != 0 {
+		t.Errorf("expected a game over without any guess, got %s lasting %s", g.Status(), g.Duration())
+	}
+}
+
+// readerFunc implements io.Reader with a function.
+type readerFunc func(p []byte) (int, error)
+
+// Read implements the io.Reader interface.
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestSpeedrun(t *testing.T) {
+	clock := newFakeClock()
+	output := &strings.Builder{}
+
+	// every line takes 10 seconds to type
+	lines := []string{"hello\n", "plant\n", "world\n"}
+	reader := readerFunc(func(p []byte) (int, error) {
+		if len(lines) == 0 {
+			return 0, errors.New("no more lines")
+		}
+		clock.advance(10 * time.Second)
+		n := copy(p, lines[0])
+		lines = lines[1:]
+		return n, nil
+	})
+
+	// the seed picks HELLO first
+	result, err := gordle.Speedrun(reader, []string{"HELLO", "WORLD"}, 6, 2,
+		gordle.WithSeed(2), gordle.WithClock(clock.Now), gordle.WithOutput(output), gordle.WithErrorOutput(output))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the second game can't pick HELLO again
+	expected := gordle.SpeedrunResult{Games: 2, Won: 2, Guesses: 3, Duration: 30 * time.Second}
+	if result != expected {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+
+	if !strings.Contains(output.String(), "Word 2 of 2") {
+		t.Errorf("expected the games to be numbered, got %q", output.String())
+	}
+
+	_, err = gordle.Speedrun(reader, []string{"HELLO"}, 6, 2)
+	if !errors.Is(err, gordle.ErrNotEnoughWords) {
+		t.Errorf("expected %v, got %v", gordle.ErrNotEnoughWords, err)
+	}
+
+	_, err = gordle.Speedrun(reader, []string{"HELLO"}, 0, 1)
+	if err == nil {
+		t.Errorf("expected an error for a speedrun without attempts")
+	}
+}
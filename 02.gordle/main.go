@@ -2,26 +2,230 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"gordle/gordle"
 	"os"
+	"time"
 )
 
 const maxAttempts = 6
 
+// languageFiles are the names of the corpus and dictionary bundled for each language.
+// An empty dictionary allows any word.
+var languageFiles = map[string]struct{ corpus, dictionary string }{
+	"en": {corpus: englishCorpus, dictionary: englishDictionary},
+	"fr": {corpus: "french.txt"},
+	"es": {corpus: "spanish.txt"},
+}
+
+// commands lists the subcommands, which receive the rest of the command line.
+// Without a subcommand, a game is played in the terminal.
+var commands = map[string]func(args []string) error{
+	"corpus": checkCorpus,
+	"race":   runRace,
+	"replay": replay,
+	"serve":  serve,
+	"solve":  solve,
+	"stats":  showStats,
+}
+
 func main() {
-	corpus, err := gordle.ReadCorpus("corpus/english.txt")
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			err := command(os.Args[2:])
+			if err != nil && !errors.Is(err, flag.ErrHelp) {
+				_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	err := play(os.Args[1:])
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+// play runs a game in the terminal.
+func play(args []string) error {
+	flags := flag.NewFlagSet("gordle", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: gordle [corpus|race|replay|serve|solve|stats] [flags]\n\n"+
+			"Without a command, play a game of Gordle in the terminal. Flags of the game:\n")
+		flags.PrintDefaults()
+	}
+	lang := flags.String("lang", "en", "Language of the words: en, fr, es, de or tr (defaults to the language in the header of the corpus)")
+	corpusPath := flags.String("corpus", "", "Path to the list of words the solution is picked from (defaults to the corpus bundled for the language)")
+	dictionaryPath := flags.String("dictionary", "", "Path to the list of allowed guesses (defaults to the dictionary bundled for the language, with the bundled corpus); none allows any word")
+	attempts := flags.Int("attempts", maxAttempts, "Number of guesses allowed to find the word")
+	wordLength := flags.Int("length", 0, "Only pick solutions with this number of characters (0 allows any length)")
+	difficulty := flags.String("difficulty", "normal", "Difficulty of the solution, from the frequency of the words: easy picks common words, hard rare ones")
+	daily := flags.Bool("daily", false, "Play the word of the day, the same for every player")
+	seed := flags.Int64("seed", 0, "Seed picking the solution, to replay the same game (0 picks a random word)")
+	statsPath := flags.String("stats", "", "Path to the statistics file (defaults to gordle/stats.json in the user configuration directory)")
+	shareDest := flags.String("share", "-", "Where to write the shareable result at the end of the game: a file path, - for the standard output, or empty to skip it")
+	highContrast := flags.Bool("high-contrast", false, "Use high contrast colours in the shareable result")
+	savePath := flags.String("save", "", "Save the game to this file after every guess, to resume it later")
+	resumePath := flags.String("resume", "", "Resume the game saved in this file, and keep saving it there unless -save is set")
+	plain := flags.Bool("plain", false, "Read the guesses line by line instead of playing full screen")
+	timeLimit := flags.Duration("time-limit", 0, "Time allowed to find the word, such as 2m (0 allows any time)")
+	guessTimeLimit := flags.Duration("guess-time-limit", 0, "Time allowed for every guess, such as 30s (0 allows any time)")
+	speedrun := flags.Int("speedrun", 0, "Play this number of words in a row, and report the total time and guesses")
+	logPath := flags.String("log", "", "Append the events of the game to this file, to watch it again with the replay command")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *attempts < 1 {
+		return fmt.Errorf("invalid number of attempts %d, expected at least 1", *attempts)
+	}
+
+	level, err := gordle.ParseDifficulty(*difficulty)
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "unable to read corpus: %s", err)
-		return
+		return err
 	}
 
-	// create the game
-	g, err := gordle.New(bufio.NewReader(os.Stdin), corpus, maxAttempts)
+	// a corpus chosen by the player tells its language in its header, unless -lang overrides it.
+	var file gordle.Corpus
+	if *corpusPath != "" {
+		file, err = readCorpus(*corpusPath, "")
+		if err != nil {
+			return fmt.Errorf("unable to read corpus: %w", err)
+		}
+
+		if file.Language != "" && !isFlagSet(flags, "lang") {
+			*lang = file.Language
+		}
+	}
+
+	language, err := gordle.LanguageByCode(*lang)
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "unable to start game: %s", err)
+		return err
+	}
+
+	// the bundled files of the language are used unless the player chose other ones
+	bundled, ok := languageFiles[language.Code]
+	if *corpusPath == "" {
+		if !ok {
+			return fmt.Errorf("no corpus is bundled for language %q, use -corpus", language.Code)
+		}
+
+		file, err = readCorpus("", bundled.corpus)
+		if err != nil {
+			return fmt.Errorf("unable to read corpus: %w", err)
+		}
+	}
+
+	corpus := file.Solutions()
+	if len(corpus) == 0 {
+		return fmt.Errorf("unable to read corpus: %w", gordle.ErrCorpusIsEmpty)
+	}
+
+	if *wordLength != 0 {
+		corpus, err = gordle.FilterByLength(corpus, *wordLength, language)
+		if err != nil {
+			return fmt.Errorf("invalid word length: %w", err)
+		}
+	}
+
+	now := time.Now()
+
+	opts := []gordle.Option{gordle.WithLanguage(language), gordle.WithDifficulty(level, file.Frequencies()),
+		gordle.WithWordDifficulties(file.Difficulties())}
+	dictionary, err := dictionaryOptions(*dictionaryPath, bundled.dictionary, *corpusPath == "", language)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dictionary...)
+	if *timeLimit > 0 {
+		opts = append(opts, gordle.WithTimeLimit(*timeLimit))
+	}
+	if *guessTimeLimit > 0 {
+		opts = append(opts, gordle.WithGuessTimeLimit(*guessTimeLimit))
+	}
+	switch {
+	case *speedrun > 0 && (*daily || *resumePath != "" || *savePath != ""):
+		return fmt.Errorf("a speedrun can't be combined with -daily, -save or -resume")
+	case *daily:
+		opts = append(opts, gordle.WithDailyWord(now))
+	case *seed != 0:
+		opts = append(opts, gordle.WithSeed(*seed))
+	}
+
+	if *logPath != "" {
+		log, err := os.OpenFile(*logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			return fmt.Errorf("unable to open event log: %w", err)
+		}
+		defer log.Close()
+
+		opts = append(opts, gordle.WithEventLog(log))
+	}
+
+	if *speedrun > 0 {
+		return runSpeedrun(corpus, *attempts, *speedrun, opts...)
+	}
+
+	if *savePath == "" {
+		*savePath = *resumePath
+	}
+	if *savePath != "" {
+		opts = append(opts, gordle.WithObserver(autosave(*savePath)))
+	}
+
+	// create the game, or restore it
+	var g *gordle.Game
+	if *resumePath != "" {
+		g, err = resumeGame(*resumePath, bufio.NewReader(os.Stdin), opts...)
+	} else {
+		g, err = gordle.New(bufio.NewReader(os.Stdin), corpus, *attempts, opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to start game: %w", err)
 	}
 
 	// Run the game! It will end when it's over
-	g.Play()
+	err = playInTerminal(g, *plain)
+	if err != nil {
+		return err
+	}
+
+	played, err := recordResult(*statsPath, g)
+	if err != nil {
+		return err
+	}
+
+	// daily games are numbered after the day, so that players can compare their results.
+	number := played
+	if *daily {
+		number = gordle.DailyNumber(now)
+	}
+
+	return share(g, number, *shareDest, *highContrast)
+}
+
+// isFlagSet tells whether the flag was given on the command line.
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// runSpeedrun plays games in a row, line by line, and prints the total.
+func runSpeedrun(corpus []string, attempts, games int, opts ...gordle.Option) error {
+	result, err := gordle.Speedrun(os.Stdin, corpus, attempts, games, opts...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nSpeedrun over: %d/%d words found, in %d guesses and %s.\n",
+		result.Won, result.Games, result.Guesses, result.Duration.Round(time.Millisecond))
+	return nil
 }
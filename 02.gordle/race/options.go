@@ -0,0 +1,30 @@
+package race
+
+import (
+	"gordle/gordle"
+	"time"
+)
+
+// Option defines a functional option to a Race.
+type Option func(*Race)
+
+// WithPlayers sets the number of players the race waits for before starting. The default is 2.
+func WithPlayers(n int) Option {
+	return func(r *Race) {
+		r.minPlayers = n
+	}
+}
+
+// WithGameOptions sets the options of the game of every player, such as a dictionary.
+func WithGameOptions(opts ...gordle.Option) Option {
+	return func(r *Race) {
+		r.gameOpts = opts
+	}
+}
+
+// WithClock sets the function timing the players. The default is time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(r *Race) {
+		r.now = now
+	}
+}
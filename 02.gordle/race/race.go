@@ -0,0 +1,413 @@
+// Package race runs Gordle races: players connect over TCP, and compete to find the same word.
+//
+// The protocol is line-based, so that players can join with a tool such as netcat.
+// The server asks for the name of the player, then every line sent by the player is a guess.
+// The race starts once enough players have joined. The progress of the opponents is broadcast
+// as rows of hints, without revealing their letters.
+// Once every player has finished, the ranking is sent: the players who found the word,
+// by number of guesses then by time, followed by the others.
+package race
+
+import (
+	"bufio"
+	"fmt"
+	"gordle/gordle"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ErrRaceStarted is sent to players joining a race that has already started.
+	ErrRaceStarted = raceError("the race has already started")
+	// ErrRaceOver is sent to players joining a race that is over.
+	ErrRaceOver = raceError("the race is over")
+	// ErrInvalidAttempts is returned when a race is created with fewer than one attempt: it could never end.
+	ErrInvalidAttempts = raceError("a race needs at least one attempt")
+)
+
+// raceError defines a sentinel error.
+type raceError string
+
+// Error is the implementation of the error interface by raceError
+func (e raceError) Error() string {
+	return string(e)
+}
+
+// outputBuffer is the number of messages waiting to be sent to a player.
+// Messages to a player who doesn't read them are dropped once the buffer is full.
+const outputBuffer = 64
+
+// Race is a Gordle race, played by every player connected to it. It is safe for concurrent use.
+type Race struct {
+	solution    string
+	maxAttempts int
+	minPlayers  int
+	gameOpts    []gordle.Option
+	now         func() time.Time
+
+	// mutex protects the fields below.
+	mutex   sync.Mutex
+	players []*player
+	// waiting holds the connected players who haven't told their name yet.
+	waiting map[*player]struct{}
+	// started is the time the race started, zero until enough players have joined.
+	started time.Time
+	over    bool
+	// done is closed once the race is over.
+	done chan struct{}
+	// handlers counts the connections handled by Serve.
+	handlers sync.WaitGroup
+}
+
+// player is a contestant of the race.
+type player struct {
+	name string
+	// out holds the messages sent to the player, it is closed when the player leaves or the race is over.
+	out    chan string
+	closed bool
+	// finished is set once the player won, lost or left.
+	finished bool
+	left     bool
+	won      bool
+	guesses  int
+	duration time.Duration
+}
+
+// New returns a race whose solution is picked from the corpus.
+// It starts once two players have joined, unless an option says otherwise.
+func New(corpus []string, maxAttempts int, opts ...Option) (*Race, error) {
+	if len(corpus) == 0 {
+		return nil, gordle.ErrCorpusIsEmpty
+	}
+
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidAttempts, maxAttempts)
+	}
+
+	r := &Race{
+		maxAttempts: maxAttempts,
+		minPlayers:  2,
+		now:         time.Now,
+		waiting:     make(map[*player]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	// every player gets a game of their own, with the same solution
+	r.solution = corpus[rand.New(rand.NewSource(time.Now().UnixNano())).Intn(len(corpus))]
+
+	return r, nil
+}
+
+// Done returns a channel closed once the race is over.
+func (r *Race) Done() <-chan struct{} {
+	return r.done
+}
+
+// Serve accepts players on the listener, until the race is over or the listener fails.
+// It returns nil once the race is over and the ranking was sent to every player.
+// The caller should close the listener once the race is over, to stop accepting players.
+func (r *Race) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-r.done:
+				r.handlers.Wait()
+				return nil
+			default:
+				return fmt.Errorf("unable to accept player: %w", err)
+			}
+		}
+
+		r.handlers.Add(1)
+		go func() {
+			defer r.handlers.Done()
+			r.Handle(conn)
+		}()
+	}
+}
+
+// Handle plays the race with the player connected on conn, until the player leaves or the race is over.
+// It closes the connection.
+func (r *Race) Handle(conn net.Conn) {
+	p := &player{out: make(chan string, outputBuffer)}
+
+	// the messages are written by a goroutine of their own, so that a slow player doesn't block the race
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		defer conn.Close()
+		for line := range p.out {
+			// keep draining the messages even if the connection failed
+			_, _ = fmt.Fprintf(conn, "%s\n", line)
+		}
+	}()
+
+	if !r.welcome(p) {
+		<-written
+		return
+	}
+
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() {
+		r.dismiss(p, "")
+		<-written
+		return
+	}
+
+	game, err := r.join(p, strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		r.dismiss(p, fmt.Sprintf("Sorry, %s.", err))
+		<-written
+		return
+	}
+
+	for scanner.Scan() {
+		r.guess(p, game, strings.TrimSpace(scanner.Text()))
+	}
+
+	r.leave(p)
+	<-written
+}
+
+// welcome asks the name of a new player. It returns false, and closes the messages of the player, if the race is over.
+func (r *Race) welcome(p *player) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.over {
+		r.send(p, fmt.Sprintf("Sorry, %s.", ErrRaceOver))
+		p.closed = true
+		close(p.out)
+		return false
+	}
+
+	// the player is dismissed if the race ends before they tell their name, so that Serve doesn't wait for them.
+	r.waiting[p] = struct{}{}
+	r.send(p, "Welcome to Gordle race! What's your name?")
+	return true
+}
+
+// dismiss sends a last message, if any, to a player who didn't join, and closes their messages.
+func (r *Race) dismiss(p *player, message string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.waiting, p)
+	if p.closed {
+		return
+	}
+
+	if message != "" {
+		r.send(p, message)
+	}
+	p.closed = true
+	close(p.out)
+}
+
+// join adds a player to the race, and starts it if enough players have joined.
+func (r *Race) join(p *player, name string) (*gordle.Game, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.waiting, p)
+
+	switch {
+	case r.over:
+		return nil, ErrRaceOver
+	case !r.started.IsZero():
+		return nil, ErrRaceStarted
+	}
+
+	game, err := gordle.New(nil, []string{r.solution}, r.maxAttempts, r.gameOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("player %d", len(r.players)+1)
+	}
+	p.name = name
+	r.players = append(r.players, p)
+
+	if missing := r.minPlayers - len(r.players); missing > 0 {
+		r.send(p, fmt.Sprintf("Hello %s, waiting for %d more player(s).", p.name, missing))
+		return game, nil
+	}
+
+	r.started = r.now()
+	r.broadcast(nil, fmt.Sprintf("Go! Find the %d-character word in %d attempts.", game.WordLength(), r.maxAttempts))
+
+	return game, nil
+}
+
+// guess plays a word in the game of the player, and tells the others how close the player got.
+func (r *Race) guess(p *player, game *gordle.Game, word string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch {
+	case r.started.IsZero():
+		r.send(p, fmt.Sprintf("The race hasn't started, waiting for %d more player(s).", r.minPlayers-len(r.players)))
+		return
+	case p.finished:
+		r.send(p, "You have finished, waiting for the other players.")
+		return
+	}
+
+	fb, status, err := game.Guess(word)
+	if err != nil {
+		r.send(p, fmt.Sprintf("Invalid guess: %s.", err))
+		return
+	}
+
+	attempts := game.Attempts()
+	r.send(p, fmt.Sprintf("%s %s", fb, attempts[len(attempts)-1].Word))
+	r.broadcast(p, fmt.Sprintf("%s: %s", p.name, fb))
+
+	switch status {
+	case gordle.StatusWon:
+		p.finished, p.won, p.guesses, p.duration = true, true, len(attempts), r.now().Sub(r.started)
+		r.send(p, fmt.Sprintf("You found it in %d guess(es)!", p.guesses))
+		r.broadcast(p, fmt.Sprintf("%s found the word in %d guess(es)!", p.name, p.guesses))
+	case gordle.StatusLost:
+		p.finished, p.guesses, p.duration = true, len(attempts), r.now().Sub(r.started)
+		r.send(p, "You've run out of attempts.")
+		r.broadcast(p, fmt.Sprintf("%s is out of attempts.", p.name))
+	}
+
+	r.endIfFinished()
+}
+
+// leave removes a disconnected player from the race.
+func (r *Race) leave(p *player) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if p.closed {
+		// the race is over, the ranking was sent.
+		return
+	}
+
+	p.closed = true
+	close(p.out)
+
+	if r.started.IsZero() {
+		// nobody is racing yet, the player's seat is given to the next one.
+		for i, other := range r.players {
+			if other == p {
+				r.players = append(r.players[:i], r.players[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+
+	if !p.finished {
+		p.finished, p.left = true, true
+		r.broadcast(p, fmt.Sprintf("%s left the race.", p.name))
+	}
+
+	r.endIfFinished()
+}
+
+// endIfFinished sends the ranking to every player once they have all finished.
+// It must be called with the mutex held.
+func (r *Race) endIfFinished() {
+	for _, p := range r.players {
+		if !p.finished {
+			return
+		}
+	}
+
+	r.over = true
+	r.broadcast(nil, fmt.Sprintf("Race over! The word was %s.", strings.ToUpper(r.solution)))
+
+	ranking := r.ranking()
+	for i, p := range ranking {
+		switch {
+		case p.won:
+			r.broadcast(nil, fmt.Sprintf("%d. %s found it in %d guess(es), in %s", i+1, p.name, p.guesses, p.duration))
+		case p.left:
+			r.broadcast(nil, fmt.Sprintf("%d. %s left the race", i+1, p.name))
+		default:
+			r.broadcast(nil, fmt.Sprintf("%d. %s didn't find it", i+1, p.name))
+		}
+	}
+
+	if len(ranking) > 0 && ranking[0].won {
+		r.broadcast(nil, fmt.Sprintf("%s wins!", ranking[0].name))
+	} else {
+		r.broadcast(nil, "Nobody found the word.")
+	}
+
+	for _, p := range r.players {
+		if !p.closed {
+			p.closed = true
+			close(p.out)
+		}
+	}
+
+	// closing their messages closes the connection of the players who never told their name.
+	for p := range r.waiting {
+		r.send(p, fmt.Sprintf("Sorry, %s.", ErrRaceOver))
+		p.closed = true
+		close(p.out)
+	}
+	r.waiting = make(map[*player]struct{})
+
+	close(r.done)
+}
+
+// ranking returns the players, best first: those who found the word by number of guesses then by time,
+// then those who didn't, in the order they joined.
+func (r *Race) ranking() []*player {
+	ranking := make([]*player, len(r.players))
+	copy(ranking, r.players)
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		switch {
+		case a.won != b.won:
+			return a.won
+		case !a.won:
+			return false
+		case a.guesses != b.guesses:
+			return a.guesses < b.guesses
+		default:
+			return a.duration < b.duration
+		}
+	})
+
+	return ranking
+}
+
+// send queues a message for a player. It must be called with the mutex held.
+func (r *Race) send(p *player, message string) {
+	if p.closed {
+		return
+	}
+
+	select {
+	case p.out <- message:
+	default:
+		// the player doesn't read the messages, drop this one.
+	}
+}
+
+// broadcast sends a message to every player but one, which may be nil. It must be called with the mutex held.
+func (r *Race) broadcast(except *player, message string) {
+	for _, p := range r.players {
+		if p != except {
+			r.send(p, message)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package race_test
+
+import (
+	"bufio"
+	"errors"
+	"gordle/race"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// client is a player connected to a race.
+type client struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *client {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("unable to connect: %s", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &client{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// send writes a line to the server.
+func (c *client) send(line string) {
+	c.t.Helper()
+
+	_, err := io.WriteString(c.conn, line+"\n")
+	if err != nil {
+		c.t.Fatalf("unable to send %q: %s", line, err)
+	}
+}
+
+// expect reads the next lines from the server, and checks them.
+func (c *client) expect(lines ...string) {
+	c.t.Helper()
+
+	for _, expected := range lines {
+		_ = c.conn.SetReadDeadline(time.Now().Add(time.Second))
+		got, err := c.reader.ReadString('\n')
+		if err != nil {
+			c.t.Fatalf("expected %q, got error %s", expected, err)
+		}
+
+		if got != expected+"\n" {
+			c.t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+}
+
+// skip reads the next lines from the server, and ignores them.
+func (c *client) skip(n int) {
+	c.t.Helper()
+
+	for i := 0; i < n; i++ {
+		_ = c.conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, err := c.reader.ReadString('\n')
+		if err != nil {
+			c.t.Fatalf("unable to read: %s", err)
+		}
+	}
+}
+
+// expectClosed checks the server closed the connection.
+func (c *client) expectClosed() {
+	c.t.Helper()
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(time.Second))
+	line, err := c.reader.ReadString('\n')
+	if !errors.Is(err, io.EOF) {
+		c.t.Fatalf("expected the connection to be closed, got %q and error %v", line, err)
+	}
+}
+
+// tickingClock returns a clock moving forward by a second every time it's read.
+func tickingClock() func() time.Time {
+	var mutex sync.Mutex
+	now := time.Date(2022, 12, 3, 10, 0, 0, 0, time.UTC)
+
+	return func() time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+// startRace serves a race on a local port, and returns its address.
+func startRace(t *testing.T, opts ...race.Option) (*race.Race, string) {
+	t.Helper()
+
+	r, err := race.New([]string{"HELLO"}, 3, append([]race.Option{race.WithClock(tickingClock())}, opts...)...)
+	if err != nil {
+		t.Fatalf("unable to create race: %s", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+
+	served := make(chan error)
+	go func() { served <- r.Serve(l) }()
+	t.Cleanup(func() {
+		_ = l.Close()
+		if err := <-served; err != nil && !errors.Is(err, net.ErrClosed) {
+			t.Errorf("unexpected error while serving: %s", err)
+		}
+	})
+
+	return r, l.Addr().String()
+}
+
+func TestRace(t *testing.T) {
+	r, addr := startRace(t)
+
+	alice := dial(t, addr)
+	alice.expect("Welcome to Gordle race! What's your name?")
+	alice.send("alice")
+	alice.expect("Hello alice, waiting for 1 more player(s).")
+
+	alice.send("hello")
+	alice.expect("The race hasn't started, waiting for 1 more player(s).")
+
+	bob := dial(t, addr)
+	bob.expect("Welcome to Gordle race! What's your name?")
+	bob.send("bob")
+	alice.expect("Go! Find the 5-character word in 3 attempts.")
+	bob.expect("Go! Find the 5-character word in 3 attempts.")
+
+	// the opponents see the hints, but not the letters
+	alice.send("holes")
+	alice.expect("💚🟡💚🟡⬜️ HOLES")
+	bob.expect("alice: 💚🟡💚🟡⬜️")
+
+	bob.send("hi")
+	bob.expect("Invalid guess: expected 5, got 2, invalid quess, word doesn't have the same number of characters as the solution.")
+
+	bob.send("hello")
+	bob.expect("💚💚💚💚💚 HELLO", "You found it in 1 guess(es)!")
+	alice.expect("bob: 💚💚💚💚💚", "bob found the word in 1 guess(es)!")
+
+	bob.send("hello")
+	bob.expect("You have finished, waiting for the other players.")
+
+	late := dial(t, addr)
+	late.expect("Welcome to Gordle race! What's your name?")
+	late.send("carol")
+	late.expect("Sorry, the race has already started.")
+	late.expectClosed()
+
+	alice.send("hello")
+	alice.expect("💚💚💚💚💚 HELLO", "You found it in 2 guess(es)!")
+	bob.expect("alice: 💚💚💚💚💚", "alice found the word in 2 guess(es)!")
+
+	ranking := []string{
+		"Race over! The word was HELLO.",
+		"1. bob found it in 1 guess(es), in 1s",
+		"2. alice found it in 2 guess(es), in 2s",
+		"bob wins!",
+	}
+	alice.expect(ranking...)
+	alice.expectClosed()
+	bob.expect(ranking...)
+	bob.expectClosed()
+
+	select {
+	case <-r.Done():
+	case <-time.After(time.Second):
+		t.Errorf("expected the race to be over")
+	}
+}
+
+func TestRace_Ranking(t *testing.T) {
+	_, addr := startRace(t, race.WithPlayers(3))
+
+	players := make(map[string]*client)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		players[name] = dial(t, addr)
+		players[name].expect("Welcome to Gordle race! What's your name?")
+		players[name].send(name)
+		if name != "carol" {
+			// wait for the player to join, to keep the order of the players
+			players[name].skip(1)
+		}
+	}
+	for _, p := range players {
+		p.expect("Go! Find the 5-character word in 3 attempts.")
+	}
+
+	_ = players["carol"].conn.Close()
+	players["alice"].expect("carol left the race.")
+	players["bob"].expect("carol left the race.")
+
+	for _, word := range []string{"salut", "plant", "world"} {
+		players["alice"].send(word)
+		players["alice"].skip(1)
+		players["bob"].skip(1)
+	}
+	players["alice"].expect("You've run out of attempts.")
+	players["bob"].expect("alice is out of attempts.")
+
+	players["bob"].send("hello")
+	players["bob"].expect("💚💚💚💚💚 HELLO", "You found it in 1 guess(es)!")
+	players["alice"].expect("bob: 💚💚💚💚💚", "bob found the word in 1 guess(es)!")
+
+	ranking := []string{
+		"Race over! The word was HELLO.",
+		"1. bob found it in 1 guess(es), in 2s",
+		"2. alice didn't find it",
+		"3. carol left the race",
+		"bob wins!",
+	}
+	players["alice"].expect(ranking...)
+	players["bob"].expect(ranking...)
+}
+
+func TestRace_SilentClient(t *testing.T) {
+	r, addr := startRace(t)
+
+	silent := dial(t, addr)
+	silent.expect("Welcome to Gordle race! What's your name?")
+
+	alice := dial(t, addr)
+	alice.expect("Welcome to Gordle race! What's your name?")
+	alice.send("alice")
+	alice.skip(1)
+
+	bob := dial(t, addr)
+	bob.expect("Welcome to Gordle race! What's your name?")
+	bob.send("bob")
+	alice.skip(1)
+	bob.skip(1)
+
+	alice.send("hello")
+	alice.skip(2)
+	bob.skip(2)
+	bob.send("hello")
+
+	select {
+	case <-r.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected the race to be over")
+	}
+
+	// the player who never told their name is sent away, so that Serve can return
+	silent.expect("Sorry, the race is over.")
+	silent.expectClosed()
+}
+
+func TestNew_InvalidAttempts(t *testing.T) {
+	for _, attempts := range []int{0, -1} {
+		_, err := race.New([]string{"HELLO"}, attempts)
+		if !errors.Is(err, race.ErrInvalidAttempts) {
+			t.Errorf("expected %v for %d attempts, got %v", race.ErrInvalidAttempts, attempts, err)
+		}
+	}
+}
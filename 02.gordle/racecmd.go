@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/gordle"
+	"gordle/race"
+	"net"
+)
+
+// runRace hosts a race over TCP, until it's over.
+func runRace(args []string) error {
+	flags := flag.NewFlagSet("race", flag.ContinueOnError)
+	addr := flags.String("addr", ":4242", "Address the race listens on")
+	players := flags.Int("players", 2, "Number of players the race waits for before starting")
+	lang := flags.String("lang", "en", "Language of the words: en, fr, es, de or tr (defaults to the language in the header of the corpus)")
+	corpusPath := flags.String("corpus", "", "Path to the list of words the solution is picked from (defaults to the corpus bundled for the language)")
+	dictionaryPath := flags.String("dictionary", "", "Path to the list of allowed guesses (defaults to the dictionary bundled for the language, with the bundled corpus); none allows any word")
+	attempts := flags.Int("attempts", maxAttempts, "Number of guesses allowed to find the word")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *players < 1 {
+		return fmt.Errorf("invalid number of players %d, expected at least 1", *players)
+	}
+
+	if *attempts < 1 {
+		return fmt.Errorf("invalid number of attempts %d, expected at least 1", *attempts)
+	}
+
+	// as in play, a corpus chosen by the host tells its language in its header, unless -lang overrides it.
+	var file gordle.Corpus
+	if *corpusPath != "" {
+		file, err = readCorpus(*corpusPath, "")
+		if err != nil {
+			return fmt.Errorf("unable to read corpus: %w", err)
+		}
+
+		if file.Language != "" && !isFlagSet(flags, "lang") {
+			*lang = file.Language
+		}
+	}
+
+	language, err := gordle.LanguageByCode(*lang)
+	if err != nil {
+		return err
+	}
+
+	bundled, ok := languageFiles[language.Code]
+	if *corpusPath == "" {
+		if !ok {
+			return fmt.Errorf("no corpus is bundled for language %q, use -corpus", language.Code)
+		}
+
+		file, err = readCorpus("", bundled.corpus)
+		if err != nil {
+			return fmt.Errorf("unable to read corpus: %w", err)
+		}
+	}
+
+	gameOpts := []gordle.Option{gordle.WithLanguage(language)}
+	dictionary, err := dictionaryOptions(*dictionaryPath, bundled.dictionary, *corpusPath == "", language)
+	if err != nil {
+		return err
+	}
+	gameOpts = append(gameOpts, dictionary...)
+
+	r, err := race.New(file.Solutions(), *attempts, race.WithPlayers(*players), race.WithGameOptions(gameOpts...))
+	if err != nil {
+		return fmt.Errorf("unable to create race: %w", err)
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen: %w", err)
+	}
+
+	// stop accepting players once the race is over
+	go func() {
+		<-r.Done()
+		_ = l.Close()
+	}()
+
+	fmt.Printf("Gordle race is waiting for %d player(s) on %s\n", *players, l.Addr())
+	return r.Serve(l)
+}
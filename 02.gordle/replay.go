@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/gordle"
+	"os"
+	"time"
+)
+
+// replay plays back the games recorded in an event log, guess by guess.
+func replay(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: gordle replay [flags] log\n\n"+
+			"Play back the games recorded with -log. Flags:\n")
+		flags.PrintDefaults()
+	}
+	delay := flags.Duration("delay", 500*time.Millisecond, "Pause between two guesses (0 prints the games at once)")
+	last := flags.Bool("last", false, "Only replay the last game of the log")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("unable to open event log: %w", err)
+	}
+	defer f.Close()
+
+	replays, err := gordle.ReadReplays(f)
+	if err != nil {
+		return err
+	}
+
+	if len(replays) == 0 {
+		return fmt.Errorf("no game was recorded in %s", flags.Arg(0))
+	}
+
+	if *last {
+		replays = replays[len(replays)-1:]
+	}
+
+	for i, r := range replays {
+		if i > 0 {
+			fmt.Println()
+		}
+		playBack(r, *delay)
+	}
+
+	return nil
+}
+
+// playBack prints a recorded game, waiting between the guesses.
+func playBack(r gordle.Replay, delay time.Duration) {
+	mode := ""
+	if r.HardMode {
+		mode = ", hard mode"
+	}
+	fmt.Printf("Game of %s: %d-character word in %d attempts%s.\n",
+		r.Started.Format("2006-01-02 15:04"), len([]rune(r.Solution)), r.MaxAttempts, mode)
+
+	for _, a := range r.Attempts {
+		time.Sleep(delay)
+		fmt.Printf("+%-6s %s %s\n", a.Time.Sub(r.Started).Round(time.Second), a.Feedback, a.Word)
+	}
+
+	switch {
+	case r.Status == gordle.StatusPlaying:
+		fmt.Printf("The game wasn't finished. The solution was %s.\n", r.Solution)
+	case r.TimedOut:
+		fmt.Printf("⏰ Time ran out after %s. The solution was %s.\n", r.Ended.Sub(r.Started).Round(time.Second), r.Solution)
+	case r.Status == gordle.StatusWon:
+		fmt.Printf("🎉 Found in %d guess(es), in %s.\n", len(r.Attempts), r.Ended.Sub(r.Started).Round(time.Second))
+	default:
+		fmt.Printf("😞 Lost. The solution was %s.\n", r.Solution)
+	}
+}
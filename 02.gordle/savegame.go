@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"gordle/gordle"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// autosave returns an observer saving the game to the file after every guess.
+// The file is removed once the game is over, as there is nothing left to resume.
+func autosave(path string) gordle.Observer {
+	return func(g *gordle.Game, _ gordle.Attempt) {
+		err := saveGame(path, g)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "unable to save the game: %s\n", err)
+		}
+	}
+}
+
+// saveGame writes the game to the file, or removes the file if the game is over.
+func saveGame(path string, g *gordle.Game) error {
+	if g.Status() != gordle.StatusPlaying {
+		err := os.Remove(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	// the game is written next to the save, which is only replaced once it's complete.
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	err = g.Save(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// resumeGame restores the game saved in the file.
+func resumeGame(path string, reader io.Reader, opts ...gordle.Option) (*gordle.Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open saved game: %w", err)
+	}
+	defer f.Close()
+
+	return gordle.Load(f, reader, opts...)
+}
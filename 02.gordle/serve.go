@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/gordle"
+	"gordle/server"
+	"net/http"
+)
+
+// serve runs the HTTP API, until the server fails.
+func serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "Address the server listens on")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	file, err := readCorpus("", englishCorpus)
+	if err != nil {
+		return fmt.Errorf("unable to read corpus: %w", err)
+	}
+
+	dictionary, err := readDictionary("", englishDictionary, gordle.English)
+	if err != nil {
+		return err
+	}
+
+	s, err := server.New(map[string][]string{"english": file.Solutions()}, "english", gordle.WithLanguage(gordle.English), gordle.WithDictionary(dictionary))
+	if err != nil {
+		return fmt.Errorf("unable to create server: %w", err)
+	}
+
+	fmt.Printf("Gordle is listening on %s\n", *addr)
+	return http.ListenAndServe(*addr, s)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"gordle/gordle"
+	"sync"
+	"time"
+)
+
+// ErrGameNotFound is returned when no game has the requested ID.
+const ErrGameNotFound = serverError("game not found")
+
+// serverError defines a sentinel error.
+type serverError string
+
+// Error is the implementation of the error interface by serverError
+func (e serverError) Error() string {
+	return string(e)
+}
+
+// storedGame is a game and the lock serialising the requests playing it.
+type storedGame struct {
+	mutex sync.Mutex
+	game  *gordle.Game
+	// lastUsed is the time of the last request on the game. It is protected by the mutex of the repository.
+	lastUsed time.Time
+}
+
+// Repository holds the games in memory, keyed by their ID. It is safe for concurrent use.
+// Games nobody played or read for longer than the TTL are removed, so that memory doesn't grow forever.
+type Repository struct {
+	mutex sync.Mutex
+	games map[string]*storedGame
+	ttl   time.Duration
+	// lastSweep is the last time expired games were removed.
+	lastSweep time.Time
+}
+
+// NewRepository returns an empty repository, keeping games for ttl after their last use.
+func NewRepository(ttl time.Duration) *Repository {
+	return &Repository{games: make(map[string]*storedGame), ttl: ttl, lastSweep: time.Now()}
+}
+
+// Add stores a game and returns its new ID.
+func (r *Repository) Add(g *gordle.Game) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	r.sweep(now)
+
+	r.games[id] = &storedGame{game: g, lastUsed: now}
+	return id, nil
+}
+
+// sweep removes the expired games. It only looks at every game once per TTL, to keep Add cheap.
+// It must be called with the mutex held.
+func (r *Repository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.ttl {
+		return
+	}
+	r.lastSweep = now
+
+	for id, stored := range r.games {
+		if now.Sub(stored.lastUsed) >= r.ttl {
+			delete(r.games, id)
+		}
+	}
+}
+
+// Do runs fn on the game with the given ID. Calls to Do on the same game are serialised.
+func (r *Repository) Do(id string, fn func(g *gordle.Game) error) error {
+	r.mutex.Lock()
+	stored, ok := r.games[id]
+	if ok {
+		stored.lastUsed = time.Now()
+	}
+	r.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%q: %w", id, ErrGameNotFound)
+	}
+
+	stored.mutex.Lock()
+	defer stored.mutex.Unlock()
+
+	return fn(stored.game)
+}
+
+// newID returns a random game ID.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate game ID: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,32 @@
+package server_test
+
+import (
+	"errors"
+	"gordle/gordle"
+	"gordle/server"
+	"testing"
+	"time"
+)
+
+func TestRepository_Expiry(t *testing.T) {
+	r := server.NewRepository(100 * time.Millisecond)
+	g, _ := gordle.New(nil, []string{"HELLO"}, 6)
+
+	idle, _ := r.Add(g)
+	used, _ := r.Add(g)
+
+	time.Sleep(60 * time.Millisecond)
+	_ = r.Do(used, func(*gordle.Game) error { return nil })
+	time.Sleep(60 * time.Millisecond)
+
+	// adding a game removes the expired ones
+	_, _ = r.Add(g)
+
+	if err := r.Do(idle, func(*gordle.Game) error { return nil }); !errors.Is(err, server.ErrGameNotFound) {
+		t.Errorf("expected the idle game to be removed, got %v", err)
+	}
+
+	if err := r.Do(used, func(*gordle.Game) error { return nil }); err != nil {
+		t.Errorf("expected the game used recently to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+// Package server exposes Gordle games through a JSON HTTP API.
+//
+//	POST /games                 creates a game: {"corpus": "english", "attempts": 6}, both optional
+//	GET  /games/{id}            returns the state of a game
+//	POST /games/{id}/guesses    plays a word: {"guess": "hello"}
+//
+// Every endpoint answers with the state of the game, or with {"error": "..."}.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gordle/gordle"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAttempts is the number of attempts of a game when the request doesn't specify it.
+const DefaultAttempts = 6
+
+// maxAttempts bounds the number of attempts a player may request.
+const maxAttempts = 20
+
+// maxBodySize bounds the size of the request bodies, in bytes.
+const maxBodySize = 1 << 10
+
+// GameTTL is how long a game is kept after the last request playing or reading it.
+const GameTTL = 24 * time.Hour
+
+// Server handles the HTTP requests of the players.
+type Server struct {
+	repository    *Repository
+	corpora       map[string][]string
+	defaultCorpus string
+	opts          []gordle.Option
+}
+
+// New returns a server offering games from the given corpora, keyed by name.
+// Games use the default corpus unless the player asks for another one.
+// The options are applied to every game.
+func New(corpora map[string][]string, defaultCorpus string, opts ...gordle.Option) (*Server, error) {
+	if _, ok := corpora[defaultCorpus]; !ok {
+		return nil, fmt.Errorf("default corpus %q is not available", defaultCorpus)
+	}
+
+	return &Server{
+		repository:    NewRepository(GameTTL),
+		corpora:       corpora,
+		defaultCorpus: defaultCorpus,
+		opts:          opts,
+	}, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "games":
+		s.route(w, r, http.MethodPost, s.createGame)
+	case len(parts) == 2 && parts[0] == "games":
+		s.route(w, r, http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			s.getGame(w, parts[1])
+		})
+	case len(parts) == 3 && parts[0] == "games" && parts[2] == "guesses":
+		s.route(w, r, http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+			s.guess(w, r, parts[1])
+		})
+	default:
+		writeError(w, http.StatusNotFound, "no such endpoint")
+	}
+}
+
+// route calls the handler if the request uses the expected method.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, method string, handler http.HandlerFunc) {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+		return
+	}
+
+	handler(w, r)
+}
+
+// createGameRequest is the body of a request creating a game.
+type createGameRequest struct {
+	Corpus   string `json:"corpus"`
+	Attempts int    `json:"attempts"`
+}
+
+// createGame starts a new game.
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	var req createGameRequest
+	// an empty body creates a game with the default settings.
+	err := decodeBody(w, r, &req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeBodyError(w, err)
+		return
+	}
+
+	if req.Corpus == "" {
+		req.Corpus = s.defaultCorpus
+	}
+	if req.Attempts == 0 {
+		req.Attempts = DefaultAttempts
+	}
+
+	corpus, ok := s.corpora[req.Corpus]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown corpus %q", req.Corpus))
+		return
+	}
+
+	if req.Attempts < 1 || req.Attempts > maxAttempts {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("attempts must be between 1 and %d", maxAttempts))
+		return
+	}
+
+	g, err := gordle.New(nil, corpus, req.Attempts, s.opts...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := s.repository.Add(g)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newGameState(id, g))
+}
+
+// getGame returns the state of a game.
+func (s *Server) getGame(w http.ResponseWriter, id string) {
+	var state gameState
+	err := s.repository.Do(id, func(g *gordle.Game) error {
+		state = newGameState(id, g)
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+// guessRequest is the body of a request playing a word.
+type guessRequest struct {
+	Guess string `json:"guess"`
+}
+
+// guess plays a word in a game.
+func (s *Server) guess(w http.ResponseWriter, r *http.Request, id string) {
+	var req guessRequest
+	err := decodeBody(w, r, &req)
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	var state gameState
+	err = s.repository.Do(id, func(g *gordle.Game) error {
+		_, _, err := g.Guess(req.Guess)
+		if err != nil {
+			return err
+		}
+
+		state = newGameState(id, g)
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, ErrGameNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, gordle.ErrGameOver):
+		writeError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		writeJSON(w, http.StatusOK, state)
+	}
+}
+
+// gameState is the representation of a game sent to the players.
+type gameState struct {
+	ID          string         `json:"id"`
+	Status      string         `json:"status"`
+	WordLength  int            `json:"wordLength"`
+	MaxAttempts int            `json:"maxAttempts"`
+	Attempts    []attemptState `json:"attempts"`
+	// Letters holds the best hint received by every character played so far.
+	Letters map[string]string `json:"letters"`
+	// Solution is only revealed once the game is over.
+	Solution string `json:"solution,omitempty"`
+}
+
+// attemptState is the representation of a guess sent to the players.
+type attemptState struct {
+	Word     string   `json:"word"`
+	Feedback []string `json:"feedback"`
+	Emoji    string   `json:"emoji"`
+}
+
+// newGameState describes the game.
+func newGameState(id string, g *gordle.Game) gameState {
+	state := gameState{
+		ID:          id,
+		Status:      g.Status().String(),
+		WordLength:  g.WordLength(),
+		MaxAttempts: g.MaxAttempts(),
+		Attempts:    []attemptState{},
+		Letters:     map[string]string{},
+		Solution:    g.Solution(),
+	}
+
+	for character, h := range g.LetterStates() {
+		state.Letters[string(character)] = hintName(h)
+	}
+
+	for _, a := range g.Attempts() {
+		hints := make([]string, len(a.Feedback))
+		for i, h := range a.Feedback {
+			hints[i] = hintName(h)
+		}
+
+		state.Attempts = append(state.Attempts, attemptState{Word: a.Word, Feedback: hints, Emoji: a.Feedback.String()})
+	}
+
+	return state
+}
+
+// hintName returns the name of a hint in the API.
+func hintName(h gordle.Hint) string {
+	switch h {
+	case gordle.AbsentCharacter:
+		return "absent"
+	case gordle.WrongPosition:
+		return "wrong-position"
+	case gordle.CorrectPosition:
+		return "correct"
+	default:
+		// This should never happen.
+		return "unknown"
+	}
+}
+
+// decodeBody reads the JSON body of the request into v, refusing bodies larger than maxBodySize.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	return json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v)
+}
+
+// writeBodyError tells the player why the body of the request was refused.
+func writeBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body is larger than %d bytes", maxBodySize))
+		return
+	}
+
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+}
+
+// writeJSON sends the value as the body of the response.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError sends an error message as the body of the response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,157 @@
+package server_test
+
+import (
+	"encoding/json"
+	"gordle/server"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// state mirrors the JSON representation of a game.
+type state struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	WordLength  int    `json:"wordLength"`
+	MaxAttempts int    `json:"maxAttempts"`
+	Attempts    []struct {
+		Word     string   `json:"word"`
+		Feedback []string `json:"feedback"`
+		Emoji    string   `json:"emoji"`
+	} `json:"attempts"`
+	Letters  map[string]string `json:"letters"`
+	Solution string            `json:"solution"`
+	Error    string            `json:"error"`
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s, err := server.New(map[string][]string{"english": {"HELLO"}, "greek": {"ΧΑΙΡΕ"}}, "english")
+	if err != nil {
+		t.Fatalf("unable to create server: %s", err)
+	}
+
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func do(t *testing.T, method, url, body string, expectedStatus int) state {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var s state
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	if err != nil {
+		t.Fatalf("invalid response body: %s", err)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("%s %s: expected status %d, got %d (%s)", method, url, expectedStatus, resp.StatusCode, s.Error)
+	}
+
+	return s
+}
+
+func TestServer_Game(t *testing.T) {
+	ts := newTestServer(t)
+
+	created := do(t, http.MethodPost, ts.URL+"/games", "", http.StatusCreated)
+	if created.ID == "" || created.Status != "playing" || created.WordLength != 5 || created.MaxAttempts != server.DefaultAttempts {
+		t.Fatalf("unexpected new game %+v", created)
+	}
+
+	guessed := do(t, http.MethodPost, ts.URL+"/games/"+created.ID+"/guesses", `{"guess":"holes"}`, http.StatusOK)
+	if len(guessed.Attempts) != 1 || guessed.Solution != "" {
+		t.Fatalf("unexpected game after a guess %+v", guessed)
+	}
+
+	expected := []string{"correct", "wrong-position", "correct", "wrong-position", "absent"}
+	if strings.Join(guessed.Attempts[0].Feedback, ",") != strings.Join(expected, ",") || guessed.Attempts[0].Emoji != "💚🟡💚🟡⬜️" {
+		t.Errorf("unexpected feedback %+v", guessed.Attempts[0])
+	}
+
+	if guessed.Letters["H"] != "correct" || guessed.Letters["O"] != "wrong-position" || guessed.Letters["S"] != "absent" {
+		t.Errorf("unexpected letters %v", guessed.Letters)
+	}
+
+	do(t, http.MethodPost, ts.URL+"/games/"+created.ID+"/guesses", `{"guess":"hi"}`, http.StatusUnprocessableEntity)
+
+	won := do(t, http.MethodPost, ts.URL+"/games/"+created.ID+"/guesses", `{"guess":"hello"}`, http.StatusOK)
+	if won.Status != "won" || won.Solution != "HELLO" {
+		t.Errorf("expected the game to be won, got %+v", won)
+	}
+
+	do(t, http.MethodPost, ts.URL+"/games/"+created.ID+"/guesses", `{"guess":"hello"}`, http.StatusConflict)
+
+	fetched := do(t, http.MethodGet, ts.URL+"/games/"+created.ID, "", http.StatusOK)
+	if fetched.Status != "won" || len(fetched.Attempts) != 2 {
+		t.Errorf("unexpected game state %+v", fetched)
+	}
+}
+
+func TestServer_CreateGame(t *testing.T) {
+	ts := newTestServer(t)
+
+	testCases := map[string]struct {
+		body   string
+		status int
+	}{
+		"other corpus and attempts": {body: `{"corpus":"greek","attempts":3}`, status: http.StatusCreated},
+		"unknown corpus":            {body: `{"corpus":"klingon"}`, status: http.StatusBadRequest},
+		"too many attempts":         {body: `{"attempts":100}`, status: http.StatusBadRequest},
+		"invalid body":              {body: `{`, status: http.StatusBadRequest},
+		"body too large":            {body: `{"corpus":"` + strings.Repeat("a", 2000) + `"}`, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			do(t, http.MethodPost, ts.URL+"/games", tc.body, tc.status)
+		})
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ts := newTestServer(t)
+
+	do(t, http.MethodGet, ts.URL+"/games/unknown", "", http.StatusNotFound)
+	do(t, http.MethodPost, ts.URL+"/games/unknown/guesses", `{"guess":"hello"}`, http.StatusNotFound)
+	do(t, http.MethodGet, ts.URL+"/games", "", http.StatusMethodNotAllowed)
+	do(t, http.MethodGet, ts.URL+"/scores", "", http.StatusNotFound)
+}
+
+func TestServer_ConcurrentGuesses(t *testing.T) {
+	ts := newTestServer(t)
+	created := do(t, http.MethodPost, ts.URL+"/games", `{"attempts":20}`, http.StatusCreated)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(ts.URL+"/games/"+created.ID+"/guesses", "application/json", strings.NewReader(`{"guess":"world"}`))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	fetched := do(t, http.MethodGet, ts.URL+"/games/"+created.ID, "", http.StatusOK)
+	if len(fetched.Attempts) != 10 {
+		t.Errorf("expected 10 attempts, got %d", len(fetched.Attempts))
+	}
+}
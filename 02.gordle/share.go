@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"gordle/gordle"
+	"os"
+)
+
+// share writes the spoiler-free result of the game to dest, a file path or - for the standard output.
+// Nothing is written if dest is empty.
+func share(g *gordle.Game, number int, dest string, highContrast bool) error {
+	palette := gordle.DefaultPalette
+	if highContrast {
+		palette = gordle.HighContrastPalette
+	}
+
+	result := g.Share(number, palette)
+
+	switch dest {
+	case "":
+		return nil
+	case "-":
+		fmt.Printf("\nShare your result:\n\n%s", result)
+		return nil
+	default:
+		err := os.WriteFile(dest, []byte(result), 0o644)
+		if err != nil {
+			return fmt.Errorf("unable to write shareable result: %w", err)
+		}
+		return nil
+	}
+}
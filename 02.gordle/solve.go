@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/solver"
+	"sort"
+	"strings"
+)
+
+// solve runs the solver against every word of the corpus and prints how well it did.
+func solve(args []string) error {
+	flags := flag.NewFlagSet("solve", flag.ContinueOnError)
+	corpusPath := flags.String("corpus", "", "Path to the corpus to benchmark (defaults to the bundled English corpus)")
+	attempts := flags.Int("attempts", maxAttempts, "Number of attempts before a game is considered lost")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	file, err := readCorpus(*corpusPath, englishCorpus)
+	if err != nil {
+		return fmt.Errorf("unable to read corpus: %w", err)
+	}
+	corpus := file.Solutions()
+
+	report, err := solver.Benchmark(corpus, *attempts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Solved %d words in %.2f guesses on average.\n", report.Games, report.Average)
+	fmt.Printf("Worst case: %d guesses, for %s.\n", report.Worst, report.WorstWord)
+	fmt.Printf("Lost games (more than %d guesses): %d.\n", *attempts, report.Lost)
+
+	counts := make([]int, 0, len(report.Distribution))
+	for n := range report.Distribution {
+		counts = append(counts, n)
+	}
+	sort.Ints(counts)
+
+	for _, n := range counts {
+		fmt.Printf("%2d | %s %d\n", n, strings.Repeat("█", report.Distribution[n]), report.Distribution[n])
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package solver
+
+import (
+	"fmt"
+	"gordle/gordle"
+	"strings"
+)
+
+// Report summarises the games played by the solver against every word of a corpus.
+type Report struct {
+	// Games is the number of words played.
+	Games int
+	// Average is the mean number of guesses needed to find a word.
+	Average float64
+	// Worst is the highest number of guesses needed, for the word WorstWord.
+	Worst     int
+	WorstWord string
+	// Distribution counts the games per number of guesses.
+	Distribution map[int]int
+	// Lost counts the games that needed more than the allowed attempts.
+	Lost int
+}
+
+// Benchmark makes the solver find every word of the corpus, and reports how many guesses it needed.
+// Games are played until the word is found; those exceeding maxAttempts are counted as lost.
+func Benchmark(corpus []string, maxAttempts int) (Report, error) {
+	report := Report{Distribution: make(map[int]int)}
+	seen := make(map[string]bool)
+	total := 0
+	// the games share their guesses: the solver picks the same word for the same feedbacks,
+	// so the opening guess, among others, is only computed once.
+	next := make(map[string]string)
+
+	for _, word := range corpus {
+		word = strings.ToUpper(word)
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		// the game can't be lost: there are never more guesses than words in the corpus.
+		g, err := gordle.New(nil, []string{word}, len(corpus)+1)
+		if err != nil {
+			return Report{}, err
+		}
+
+		guesses, err := solve(g, corpus, next)
+		if err != nil {
+			return Report{}, fmt.Errorf("unable to solve %q: %w", word, err)
+		}
+
+		n := len(guesses)
+		report.Games++
+		report.Distribution[n]++
+		total += n
+
+		if n > report.Worst {
+			report.Worst, report.WorstWord = n, word
+		}
+		if n > maxAttempts {
+			report.Lost++
+		}
+	}
+
+	if report.Games > 0 {
+		report.Average = float64(total) / float64(report.Games)
+	}
+
+	return report, nil
+}
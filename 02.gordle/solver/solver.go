@@ -0,0 +1,179 @@
+// Package solver plays Gordle automatically.
+//
+// The solver keeps the list of words that are still possible solutions, and picks the guess
+// that is expected to shrink it the most, measured by the entropy of the feedback it would receive.
+package solver
+
+import (
+	"fmt"
+	"gordle/gordle"
+	"math"
+	"strings"
+)
+
+// ErrNoCandidate is returned when no word of the corpus matches the feedback received so far.
+const ErrNoCandidate = solverError("no word of the corpus matches the feedback")
+
+// solverError defines a sentinel error.
+type solverError string
+
+// Error is the implementation of the error interface by solverError
+func (e solverError) Error() string {
+	return string(e)
+}
+
+// Solver suggests guesses from a corpus.
+type Solver struct {
+	// words are the allowed guesses.
+	words [][]rune
+	// candidates are the words that match every feedback received so far.
+	candidates [][]rune
+	// path identifies the candidates by the guesses and feedbacks that led to them.
+	path string
+	// next remembers the guess picked for each path, if set. It's shared by the solvers of a benchmark.
+	next map[string]string
+}
+
+// New returns a solver playing words of the given length from the corpus.
+func New(corpus []string, wordLength int) *Solver {
+	s := &Solver{}
+	seen := make(map[string]bool)
+
+	for _, word := range corpus {
+		w := []rune(strings.ToUpper(word))
+		if len(w) != wordLength || seen[string(w)] {
+			continue
+		}
+		seen[string(w)] = true
+		s.words = append(s.words, w)
+	}
+
+	s.candidates = s.words
+	return s
+}
+
+// Candidates returns the number of words that can still be the solution.
+func (s *Solver) Candidates() int {
+	return len(s.candidates)
+}
+
+// Update removes the candidates that would not have produced the feedback.
+func (s *Solver) Update(guess string, fb gordle.Feedback) {
+	g := []rune(strings.ToUpper(guess))
+
+	remaining := make([][]rune, 0, len(s.candidates))
+	for _, candidate := range s.candidates {
+		if gordle.ComputeFeedback(g, candidate).Equal(fb) {
+			remaining = append(remaining, candidate)
+		}
+	}
+
+	s.candidates = remaining
+	s.path += string(g) + patternKey(fb)
+}
+
+// NextGuess returns the word whose feedback tells the most about the solution.
+// Among equally informative words, a candidate is preferred, as it may win right away.
+func (s *Solver) NextGuess() (string, error) {
+	switch len(s.candidates) {
+	case 0:
+		return "", ErrNoCandidate
+	case 1, 2:
+		// guessing a candidate is at least as good as anything else.
+		return string(s.candidates[0]), nil
+	}
+
+	if guess, ok := s.next[s.path]; ok {
+		return guess, nil
+	}
+
+	isCandidate := make(map[string]bool, len(s.candidates))
+	for _, candidate := range s.candidates {
+		isCandidate[string(candidate)] = true
+	}
+
+	var (
+		best          []rune
+		bestEntropy   = -1.0
+		bestCandidate bool
+	)
+
+	for _, word := range s.words {
+		e := s.entropy(word)
+		candidate := isCandidate[string(word)]
+
+		if e > bestEntropy+epsilon || (e > bestEntropy-epsilon && candidate && !bestCandidate) {
+			best, bestEntropy, bestCandidate = word, e, candidate
+		}
+	}
+
+	if s.next != nil {
+		s.next[s.path] = string(best)
+	}
+
+	return string(best), nil
+}
+
+// epsilon is the margin under which two entropies are considered equal.
+const epsilon = 1e-9
+
+// entropy returns the expected information, in bits, given by the feedback of the guess.
+func (s *Solver) entropy(guess []rune) float64 {
+	patterns := make(map[string]int)
+	for _, candidate := range s.candidates {
+		patterns[patternKey(gordle.ComputeFeedback(guess, candidate))]++
+	}
+
+	total := float64(len(s.candidates))
+	e := 0.0
+	for _, count := range patterns {
+		p := float64(count) / total
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
+
+// patternKey returns a compact representation of a feedback, usable as a map key.
+func patternKey(fb gordle.Feedback) string {
+	key := make([]byte, len(fb))
+	for i, h := range fb {
+		key[i] = byte(h)
+	}
+	return string(key)
+}
+
+// Solve plays the game until it's over, picking words of the corpus, and returns the guesses it made.
+func Solve(g *gordle.Game, corpus []string) ([]string, error) {
+	return solve(g, corpus, nil)
+}
+
+// solve plays the game like Solve, reusing and completing the guesses of next, if set.
+func solve(g *gordle.Game, corpus []string, next map[string]string) ([]string, error) {
+	// the candidates must be spelt the way the game compares them
+	words := make([]string, len(corpus))
+	for i, word := range corpus {
+		words[i] = string(g.Language().Normalize(word))
+	}
+
+	s := New(words, g.WordLength())
+	s.next = next
+
+	var guesses []string
+	for g.Status() == gordle.StatusPlaying {
+		guess, err := s.NextGuess()
+		if err != nil {
+			return guesses, err
+		}
+
+		fb, _, err := g.Guess(guess)
+		if err != nil {
+			return guesses, fmt.Errorf("guess %q was rejected: %w", guess, err)
+		}
+
+		guesses = append(guesses, guess)
+		s.Update(guess, fb)
+	}
+
+	return guesses, nil
+}
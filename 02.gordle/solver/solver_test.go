@@ -0,0 +1,80 @@
+package solver_test
+
+import (
+	"gordle/gordle"
+	"gordle/solver"
+	"testing"
+)
+
+var corpus = []string{"HELLO", "HOTEL", "HALLS", "WORLD", "SALUT", "PLANT", "CLOUD", "MUSIC"}
+
+func TestSolver_Update(t *testing.T) {
+	s := solver.New(corpus, 5)
+
+	fb := gordle.ComputeFeedback([]rune("HOTEL"), []rune("HELLO"))
+	s.Update("hotel", fb)
+
+	if s.Candidates() != 1 {
+		t.Fatalf("expected a single candidate, got %d", s.Candidates())
+	}
+
+	guess, err := s.NextGuess()
+	if err != nil || guess != "HELLO" {
+		t.Errorf("expected HELLO, got %q (err %v)", guess, err)
+	}
+
+	s.Update("hello", gordle.Feedback{gordle.AbsentCharacter, gordle.AbsentCharacter, gordle.AbsentCharacter, gordle.AbsentCharacter, gordle.AbsentCharacter})
+	if _, err = s.NextGuess(); err != solver.ErrNoCandidate {
+		t.Errorf("expected %v, got %v", solver.ErrNoCandidate, err)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	for _, solution := range corpus {
+		t.Run(solution, func(t *testing.T) {
+			g, _ := gordle.New(nil, []string{solution}, 6)
+
+			guesses, err := solver.Solve(g, corpus)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if g.Status() != gordle.StatusWon || guesses[len(guesses)-1] != solution {
+				t.Errorf("expected to find %s, got %v", solution, guesses)
+			}
+		})
+	}
+}
+
+func TestBenchmark(t *testing.T) {
+	words, err := gordle.ReadCorpus("../corpus/english.txt")
+	if err != nil {
+		t.Fatalf("unable to read corpus: %s", err)
+	}
+
+	report, err := solver.Benchmark(words, 6)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if report.Games != len(words) || report.Lost != 0 || report.Worst > 6 || report.Average < 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	// the guesses shared by the games of the benchmark are those each game would make on its own.
+	distribution := make(map[int]int)
+	for _, word := range words {
+		g, _ := gordle.New(nil, []string{word}, len(words)+1)
+		guesses, err := solver.Solve(g, words)
+		if err != nil {
+			t.Fatalf("unable to solve %q: %s", word, err)
+		}
+		distribution[len(guesses)]++
+	}
+
+	for n, count := range distribution {
+		if report.Distribution[n] != count {
+			t.Errorf("expected %d games won in %d guesses, got %d", count, n, report.Distribution[n])
+		}
+	}
+}
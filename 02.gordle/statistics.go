@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gordle/gordle"
+	"gordle/stats"
+	"os"
+	"time"
+)
+
+// recordResult adds the result of a finished game to the statistics file, and prints the statistics.
+// It returns the number of games played.
+func recordResult(path string, g *gordle.Game) (int, error) {
+	path, err := statsPath(path)
+	if err != nil {
+		return 0, err
+	}
+
+	s, err := stats.Load(path)
+	if err != nil {
+		return 0, err
+	}
+
+	s.Add(stats.Result{
+		Date:     time.Now(),
+		Won:      g.Status() == gordle.StatusWon,
+		Guesses:  len(g.Attempts()),
+		Solution: g.Solution(),
+	})
+
+	err = s.Save(path)
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Println()
+	summary := s.Summary()
+	return summary.Played, summary.Write(os.Stdout, g.MaxAttempts())
+}
+
+// showStats prints the statistics of the player.
+func showStats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	path := flags.String("stats", "", "Path to the statistics file (defaults to gordle/stats.json in the user configuration directory)")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	*path, err = statsPath(*path)
+	if err != nil {
+		return err
+	}
+
+	s, err := stats.Load(*path)
+	if err != nil {
+		return err
+	}
+
+	return s.Summary().Write(os.Stdout, maxAttempts)
+}
+
+// statsPath returns the path of the statistics file, falling back to the default location.
+func statsPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	return stats.DefaultPath()
+}
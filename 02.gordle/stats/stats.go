@@ -0,0 +1,174 @@
+// Package stats records the results of Gordle games in a JSON file, and summarises them.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Result describes a finished game.
+type Result struct {
+	Date     time.Time `json:"date"`
+	Won      bool      `json:"won"`
+	Guesses  int       `json:"guesses"`
+	Solution string    `json:"solution"`
+}
+
+// Stats holds the results of the games of a player, oldest first.
+type Stats struct {
+	Results []Result `json:"results"`
+}
+
+// DefaultPath returns the location of the statistics file in the configuration directory of the user.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate the configuration directory: %w", err)
+	}
+
+	return filepath.Join(dir, "gordle", "stats.json"), nil
+}
+
+// Load reads the statistics file at the given path. A missing file holds no result.
+func Load(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Stats{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read statistics: %w", err)
+	}
+
+	s := &Stats{}
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode statistics %q: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Save writes the statistics to the file at the given path, creating its directory if needed.
+// The file is replaced at once, so that a failure never leaves it half written.
+func (s *Stats) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode statistics: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("unable to create statistics directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to write statistics: %w", err)
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return fmt.Errorf("unable to write statistics: %w", err)
+	}
+
+	return nil
+}
+
+// Add records the result of a game.
+func (s *Stats) Add(r Result) {
+	s.Results = append(s.Results, r)
+}
+
+// Summary aggregates the results.
+type Summary struct {
+	Played int
+	Won    int
+	// CurrentStreak is the number of games won since the last loss, MaxStreak the longest such run.
+	CurrentStreak int
+	MaxStreak     int
+	// Distribution counts the won games per number of guesses.
+	Distribution map[int]int
+}
+
+// Summary computes the summary of the results.
+func (s *Stats) Summary() Summary {
+	sum := Summary{Distribution: make(map[int]int)}
+
+	for _, r := range s.Results {
+		sum.Played++
+
+		if !r.Won {
+			sum.CurrentStreak = 0
+			continue
+		}
+
+		sum.Won++
+		sum.Distribution[r.Guesses]++
+		sum.CurrentStreak++
+		if sum.CurrentStreak > sum.MaxStreak {
+			sum.MaxStreak = sum.CurrentStreak
+		}
+	}
+
+	return sum
+}
+
+// WinPercentage returns the share of games won, between 0 and 100.
+func (sum Summary) WinPercentage() int {
+	if sum.Played == 0 {
+		return 0
+	}
+
+	return sum.Won * 100 / sum.Played
+}
+
+// histogramWidth is the length of the longest bar of the guess distribution.
+const histogramWidth = 20
+
+// Write prints the summary, with a histogram of the guess distribution from 1 to maxAttempts guesses.
+// The histogram grows to the largest number of guesses of a won game, if games were played with more attempts.
+func (sum Summary) Write(w io.Writer, maxAttempts int) error {
+	sb := strings.Builder{}
+
+	for guesses := range sum.Distribution {
+		if guesses > maxAttempts {
+			maxAttempts = guesses
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("Played: %d | Win %%: %d | Current streak: %d | Max streak: %d\n",
+		sum.Played, sum.WinPercentage(), sum.CurrentStreak, sum.MaxStreak))
+	sb.WriteString("Guess distribution:\n")
+
+	highest := 0
+	for guesses := 1; guesses <= maxAttempts; guesses++ {
+		if sum.Distribution[guesses] > highest {
+			highest = sum.Distribution[guesses]
+		}
+	}
+
+	for guesses := 1; guesses <= maxAttempts; guesses++ {
+		count := sum.Distribution[guesses]
+
+		bar := 0
+		if highest > 0 {
+			bar = count * histogramWidth / highest
+		}
+		if count > 0 && bar == 0 {
+			bar = 1
+		}
+
+		sb.WriteString(fmt.Sprintf("%d | %s %d\n", guesses, strings.Repeat("█", bar), count))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,87 @@
+package stats_test
+
+import (
+	"gordle/stats"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gordle", "stats.json")
+
+	s, err := stats.Load(path)
+	if err != nil || len(s.Results) != 0 {
+		t.Fatalf("expected no result in a missing file, got %v (err %v)", s.Results, err)
+	}
+
+	date := time.Date(2022, time.December, 3, 10, 0, 0, 0, time.UTC)
+	s.Add(stats.Result{Date: date, Won: true, Guesses: 3, Solution: "HELLO"})
+
+	err = s.Save(path)
+	if err != nil {
+		t.Fatalf("unable to save: %s", err)
+	}
+
+	loaded, err := stats.Load(path)
+	if err != nil {
+		t.Fatalf("unable to load: %s", err)
+	}
+
+	if len(loaded.Results) != 1 || loaded.Results[0] != s.Results[0] {
+		t.Errorf("expected %v, got %v", s.Results, loaded.Results)
+	}
+}
+
+func TestStats_Summary(t *testing.T) {
+	s := &stats.Stats{}
+	for _, r := range []stats.Result{
+		{Won: true, Guesses: 3},
+		{Won: true, Guesses: 4},
+		{Won: true, Guesses: 3},
+		{Won: false, Guesses: 6},
+		{Won: true, Guesses: 2},
+	} {
+		s.Add(r)
+	}
+
+	sum := s.Summary()
+	if sum.Played != 5 || sum.Won != 4 || sum.WinPercentage() != 80 || sum.CurrentStreak != 1 || sum.MaxStreak != 3 {
+		t.Errorf("unexpected summary %+v", sum)
+	}
+
+	sb := &strings.Builder{}
+	err := sum.Write(sb, 6)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := "Played: 5 | Win %: 80 | Current streak: 1 | Max streak: 3\n" +
+		"Guess distribution:\n" +
+		"1 |  0\n" +
+		"2 | ██████████ 1\n" +
+		"3 | ████████████████████ 2\n" +
+		"4 | ██████████ 1\n" +
+		"5 |  0\n" +
+		"6 |  0\n"
+	if sb.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, sb.String())
+	}
+}
+
+func TestSummary_WriteMoreAttempts(t *testing.T) {
+	s := &stats.Stats{}
+	s.Add(stats.Result{Won: true, Guesses: 8})
+	s.Add(stats.Result{Won: true, Guesses: 2})
+
+	sb := &strings.Builder{}
+	err := s.Summary().Write(sb, 6)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if !strings.HasSuffix(sb.String(), "7 |  0\n8 | ████████████████████ 1\n") {
+		t.Errorf("expected the histogram to reach 8 guesses, got\n%s", sb.String())
+	}
+}
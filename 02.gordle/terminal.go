@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"gordle/gordle"
+	"gordle/tui"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// playInTerminal plays the game full screen, unless plain is set or the game doesn't run in a terminal.
+// In that case, the guesses are read line by line.
+func playInTerminal(g *gordle.Game, plain bool) error {
+	fd := int(os.Stdin.Fd())
+	if plain || !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return g.Play()
+	}
+
+	// raw mode sends every key press to the game, without echoing it
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("unable to set up the terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	return tui.New(g, os.Stdin, os.Stdout).Run()
+}
@@ -0,0 +1,334 @@
+// Package tui plays Gordle full screen in a terminal.
+//
+// The screen shows the board of previous guesses with coloured tiles, the guess being typed,
+// and an on-screen keyboard colouring every letter with the best hint it received.
+// It is redrawn after every key press, which requires the terminal to be in raw mode,
+// and every second when the game has a time limit, to update the countdown.
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"gordle/gordle"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/exp/slices"
+)
+
+// ErrInterrupted is returned when the player leaves before the end of the game.
+const ErrInterrupted = tuiError("game interrupted")
+
+// tuiError defines a sentinel error.
+type tuiError string
+
+// Error is the implementation of the error interface by tuiError
+func (e tuiError) Error() string {
+	return string(e)
+}
+
+// Keys with a special meaning.
+const (
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyBackspace = 0x7f
+	keyEscape    = 0x1b
+)
+
+// ANSI escape sequences.
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	reset       = "\x1b[0m"
+	red         = "\x1b[31m"
+)
+
+// tileColours are the colours of the tiles and keys, for each hint.
+var tileColours = map[gordle.Hint]string{
+	gordle.AbsentCharacter: "\x1b[97;100m",
+	gordle.WrongPosition:   "\x1b[30;43m",
+	gordle.CorrectPosition: "\x1b[30;42m",
+}
+
+// keyboardRows are the letters of the on-screen keyboard.
+var keyboardRows = []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+
+// UI draws a game in a terminal, and plays the words typed by the player.
+type UI struct {
+	game *gordle.Game
+	in   io.Reader
+	out  io.Writer
+	// input holds the keys typed for the current guess.
+	input []rune
+	// message is shown below the board, such as the reason why a guess was rejected.
+	message string
+}
+
+// New returns a UI playing the game with the keys read from in, and drawing it on out.
+func New(g *gordle.Game, in io.Reader, out io.Writer) *UI {
+	return &UI{
+		game: g,
+		in:   in,
+		out:  out,
+	}
+}
+
+// Run plays the game until it's over, or until the player presses Escape or Ctrl-C.
+// The game ends as soon as its time is up, even if the player doesn't press any key.
+func (ui *UI) Run() error {
+	// the keys are read by a goroutine of their own, so that the time limit and the countdown don't wait for them.
+	// It stops at the next key press once the game is over.
+	keys := make(chan []byte)
+	errs := make(chan error, 1)
+	go ui.readKeys(keys, errs)
+
+	var tick <-chan time.Time
+	if _, ok := ui.game.Remaining(); ok {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for ui.game.Status() == gordle.StatusPlaying {
+		ui.draw()
+
+		timeUp, stop := ui.game.Timer()
+		select {
+		case chunk, ok := <-keys:
+			stop()
+			if !ok {
+				return fmt.Errorf("unable to read key: %w", <-errs)
+			}
+
+			err := ui.handleKeys(chunk)
+			if err != nil {
+				return err
+			}
+		case <-tick:
+			stop()
+		case <-timeUp:
+		}
+	}
+
+	// no guess told the game its time was up.
+	ui.game.EndOnTimeUp()
+
+	switch {
+	case ui.game.Status() == gordle.StatusWon:
+		ui.message = fmt.Sprintf("🎉 You won! You found it in %d guess(es)!", len(ui.game.Attempts()))
+	case ui.game.TimedOut():
+		ui.message = fmt.Sprintf("⏰ Time's up! The solution was: %s.", ui.game.Solution())
+	default:
+		ui.message = fmt.Sprintf("😞 You've lost! The solution was: %s.", ui.game.Solution())
+	}
+	ui.draw()
+
+	return nil
+}
+
+// readKeys sends the bytes typed by the player, as the terminal sends them, until the input fails.
+func (ui *UI) readKeys(keys chan<- []byte, errs chan<- error) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+	for {
+		n, err := ui.in.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			keys <- chunk
+		}
+		if err != nil {
+			errs <- err
+			return
+		}
+	}
+}
+
+// handleKeys plays the keys the terminal sent at once, until the game is over.
+func (ui *UI) handleKeys(chunk []byte) error {
+	in := bufio.NewReader(bytes.NewReader(chunk))
+	for first := true; first || in.Buffered() > 0; first = false {
+		if ui.game.Status() != gordle.StatusPlaying {
+			return nil
+		}
+
+		if !first {
+			// the screen follows every key, as when they are typed one by one
+			ui.draw()
+		}
+
+		err := ui.handleKey(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleKey reads a key, and updates the game.
+func (ui *UI) handleKey(in *bufio.Reader) error {
+	key, _, err := in.ReadRune()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case key == '\r' || key == '\n':
+		ui.submit()
+	case key == keyBackspace || key == '\b':
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+		ui.message = ""
+	case key == keyCtrlC || key == keyCtrlD:
+		return ErrInterrupted
+	case key == keyEscape:
+		return ui.escape(in)
+	case unicode.IsLetter(key) || unicode.IsMark(key):
+		// the guess can't grow longer than the solution
+		if len(ui.word(append(ui.input, key))) <= ui.game.WordLength() {
+			ui.input = append(ui.input, key)
+		}
+		ui.message = ""
+	}
+
+	return nil
+}
+
+// escape skips the sequence sent by keys such as arrows, and interrupts the game if Escape was pressed alone.
+func (ui *UI) escape(in *bufio.Reader) error {
+	// the terminal sends the whole sequence at once
+	if in.Buffered() == 0 {
+		return ErrInterrupted
+	}
+
+	next, err := in.ReadByte()
+	if err != nil || (next != '[' && next != 'O') {
+		return ErrInterrupted
+	}
+
+	// the sequence ends with a byte between @ and ~
+	for in.Buffered() > 0 {
+		b, err := in.ReadByte()
+		if err != nil || (b >= '@' && b <= '~') {
+			break
+		}
+	}
+
+	return nil
+}
+
+// submit plays the typed word.
+func (ui *UI) submit() {
+	if len(ui.input) == 0 {
+		return
+	}
+
+	_, _, err := ui.game.Guess(string(ui.input))
+	switch {
+	case errors.Is(err, gordle.ErrInvalidWordLength):
+		ui.message = fmt.Sprintf("Not enough letters, expected %d.", ui.game.WordLength())
+	case errors.Is(err, gordle.ErrNotInDictionary):
+		ui.message = fmt.Sprintf("%s is not in the list of allowed words.", string(ui.word(ui.input)))
+	case err != nil:
+		ui.message = err.Error()
+	default:
+		ui.input = nil
+		ui.message = ""
+	}
+}
+
+// word returns the typed keys as the game compares them.
+func (ui *UI) word(keys []rune) []rune {
+	return ui.game.Language().Normalize(string(keys))
+}
+
+// draw writes the whole screen.
+func (ui *UI) draw() {
+	sb := strings.Builder{}
+	sb.WriteString(clearScreen)
+	sb.WriteString("Welcome to Gordle!\r\n\r\n")
+
+	attempts := ui.game.Attempts()
+	for row := 0; row < ui.game.MaxAttempts(); row++ {
+		sb.WriteString("  ")
+		switch {
+		case row < len(attempts):
+			for i, r := range []rune(attempts[row].Word) {
+				writeTile(&sb, r, tileColours[attempts[row].Feedback[i]])
+			}
+		case row == len(attempts) && ui.game.Status() == gordle.StatusPlaying:
+			typed := ui.word(ui.input)
+			for i := 0; i < ui.game.WordLength(); i++ {
+				if i < len(typed) {
+					writeTile(&sb, typed[i], "")
+				} else {
+					writeTile(&sb, '_', "")
+				}
+			}
+		default:
+			for i := 0; i < ui.game.WordLength(); i++ {
+				writeTile(&sb, '·', "")
+			}
+		}
+		sb.WriteString("\r\n")
+	}
+
+	sb.WriteString("\r\n")
+	if remaining, ok := ui.game.Remaining(); ok && ui.game.Status() == gordle.StatusPlaying {
+		_, _ = fmt.Fprintf(&sb, "⏱  %s left\r\n", remaining.Round(time.Second))
+	}
+	if ui.message != "" {
+		sb.WriteString(red + ui.message + reset)
+	}
+	sb.WriteString("\r\n\r\n")
+
+	hints := ui.game.LetterStates()
+	for _, row := range keyboardRows {
+		writeKeys(&sb, []rune(row), hints)
+	}
+
+	// letters of the language that the keyboard lacks, such as Ñ, once they were played
+	var others []rune
+	for r := range hints {
+		if !strings.ContainsRune(strings.Join(keyboardRows, ""), r) {
+			others = append(others, r)
+		}
+	}
+	if len(others) > 0 {
+		slices.Sort(others)
+		writeKeys(&sb, others, hints)
+	}
+
+	sb.WriteString("\r\nType a word and press Enter, Backspace to erase, Escape to quit.\r\n")
+
+	_, _ = io.WriteString(ui.out, sb.String())
+}
+
+// writeTile writes a character in a coloured tile. An empty colour leaves the tile blank.
+func writeTile(sb *strings.Builder, r rune, colour string) {
+	if colour == "" {
+		_, _ = fmt.Fprintf(sb, " %c  ", r)
+		return
+	}
+
+	_, _ = fmt.Fprintf(sb, "%s %c %s ", colour, r, reset)
+}
+
+// writeKeys writes a row of the keyboard.
+func writeKeys(sb *strings.Builder, keys []rune, hints map[rune]gordle.Hint) {
+	sb.WriteString("  ")
+	for _, r := range keys {
+		colour := ""
+		if h, ok := hints[r]; ok {
+			colour = tileColours[h]
+		}
+		writeTile(sb, r, colour)
+	}
+	sb.WriteString("\r\n")
+}
@@ -0,0 +1,116 @@
+package tui_test
+
+import (
+	"bytes"
+	"errors"
+	"gordle/gordle"
+	"gordle/tui"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUI_Run(t *testing.T) {
+	testCases := map[string]struct {
+		keys     string
+		status   gordle.Status
+		err      error
+		expected string
+	}{
+		"won after editing": {
+			keys:     "hellp\x7fo\r",
+			status:   gordle.StatusWon,
+			expected: "You won! You found it in 1 guess(es)!",
+		},
+		"lost": {
+			keys:     "salut\rplant\r",
+			status:   gordle.StatusLost,
+			expected: "You've lost! The solution was: HELLO.",
+		},
+		"word not in dictionary": {
+			keys:     "zzzzz\r\x03",
+			status:   gordle.StatusPlaying,
+			err:      tui.ErrInterrupted,
+			expected: "ZZZZZ is not in the list of allowed words.",
+		},
+		"not enough letters": {
+			keys:     "hell\r\x1b",
+			status:   gordle.StatusPlaying,
+			err:      tui.ErrInterrupted,
+			expected: "Not enough letters, expected 5.",
+		},
+		"arrow keys are ignored": {
+			keys:     "hel\x1b[Dlo\r",
+			status:   gordle.StatusWon,
+			expected: "You won!",
+		},
+		"extra letters are ignored": {
+			keys:     "helloooo\r",
+			status:   gordle.StatusWon,
+			expected: "You won!",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dictionary := gordle.NewDictionary([]string{"SALUT", "PLANT"}, gordle.DefaultLanguage)
+			g, err := gordle.New(nil, []string{"hello"}, 2, gordle.WithDictionary(dictionary))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			out := strings.Builder{}
+			err = tui.New(g, strings.NewReader(tc.keys), &out).Run()
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+
+			if g.Status() != tc.status {
+				t.Errorf("expected status %s, got %s", tc.status, g.Status())
+			}
+
+			if !strings.Contains(out.String(), tc.expected) {
+				t.Errorf("expected the screen to contain %q, got %q", tc.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestUI_RunIdle(t *testing.T) {
+	log := &bytes.Buffer{}
+	g, err := gordle.New(nil, []string{"hello"}, 6, gordle.WithTimeLimit(50*time.Millisecond), gordle.WithEventLog(log))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the player never presses a key
+	idle, typing := io.Pipe()
+	defer typing.Close()
+
+	out := &strings.Builder{}
+	done := make(chan error)
+	go func() { done <- tui.New(g, idle, out).Run() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the game to end when the time is up")
+	}
+
+	if !g.TimedOut() || !strings.Contains(out.String(), "Time's up! The solution was: HELLO.") {
+		t.Errorf("expected the game to be lost on time, got %q", out.String())
+	}
+
+	// the end of the game is logged, though no guess was made
+	replays, err := gordle.ReadReplays(log)
+	if err != nil {
+		t.Fatalf("unable to read event log: %s", err)
+	}
+	if len(replays) != 1 || replays[0].Status != gordle.StatusLost || !replays[0].TimedOut {
+		t.Errorf("expected a single game lost on time, got %+v", replays)
+	}
+}